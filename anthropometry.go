@@ -52,6 +52,109 @@ var NewBMI = newAnthropoRatio(
 	},
 )
 
+/**
+ * BMI for age
+ */
+
+// BMI classification methods for children and adolescents.
+const (
+	// BMIForAgeWHO classifies BMI-for-age with the WHO growth reference.
+	BMIForAgeWHO int = iota
+	// BMIForAgeCDC classifies BMI-for-age with the CDC 2000 growth charts.
+	BMIForAgeCDC
+	// BMIForAgeIOTF classifies BMI with the Cole IOTF cut-offs.
+	BMIForAgeIOTF
+)
+
+// PersonBMI represents a BMI calculated for a given person in an assessment
+// date. Adults are classified with the adult limits, while minors are
+// classified with the chosen method for their age and gender.
+type PersonBMI struct {
+	*Person
+	*Assessment
+	*AnthropometricRatio
+	Method int
+}
+
+// NewPersonBMI creates a new BMI for a person, with weight, height and the
+// method used to classify minors.
+func NewPersonBMI(p *Person, a *Assessment, weight, height float64, method int) *PersonBMI {
+	return &PersonBMI{p, a, NewBMI(weight, height), method}
+}
+
+func (b *PersonBMI) String() string {
+	rs, _ := b.Result()
+	return strings.Join(rs, "\n")
+}
+
+// GetName retrieves the name for this measurement.
+func (b *PersonBMI) GetName() string {
+	return "BMI"
+}
+
+// Result returns the measurement representation, and an optional error if any
+// violation was made for the measurement.
+func (b *PersonBMI) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := b.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	c, err := b.Classify()
+	if err != nil {
+		return rs, err
+	}
+
+	prs, err := b.prt.Result()
+	if err != nil {
+		return rs, err
+	}
+
+	rs = append(rs, prs...)
+	rs = append(
+		rs,
+		fmt.Sprintf("BMI: %2.f (kg/m^2)", v),
+		fmt.Sprintf("BMI classification: %s", c),
+	)
+	return rs, nil
+}
+
+// Classify returns the BMI classification, using adult limits from 18 years
+// on, and the chosen method for minors.
+func (b *PersonBMI) Classify() (string, error) {
+	if b.Person.AgeFromDate(b.Assessment.Date) >= 18 {
+		return b.AnthropometricRatio.Classify()
+	}
+
+	refs := map[int]*GrowthReference{
+		BMIForAgeWHO: WHOGrowthReference,
+		BMIForAgeCDC: CDCGrowthReference,
+	}
+	if ref, ok := refs[b.Method]; ok {
+		g, err := NewBMIForAge(b.Person, b.Assessment, b.Anthropometry, ref)
+		if err != nil {
+			return "", err
+		}
+		return g.Classify()
+	}
+	if b.Method != BMIForAgeIOTF {
+		return "", fmt.Errorf("Unknown BMI classification method %d", b.Method)
+	}
+
+	v, err := b.Calc()
+	if err != nil {
+		return "", err
+	}
+
+	limits, err := iotfLimits(b.Person.Gender, b.Person.AgeInMonthsFromDate(b.Assessment.Date)/12)
+	if err != nil {
+		return "", err
+	}
+	return Classifier(v, limits, IOTFClassification), nil
+}
+
 /**
  * Anthropometry
  */
@@ -112,14 +215,14 @@ func newAnthropoRatio(
 	conf *EquationConf,
 	result func(Measurer) []string,
 ) func(float64, float64) *AnthropometricRatio {
-	ai := new(AnthropometricRatio)
-	ai.lim = lim
-	ai.conf = conf
-	ai.result = result
 	return func(weight, height float64) *AnthropometricRatio {
-		ai.Anthropometry = NewAnthropometry(weight, height)
-		ai.prt = prt(weight, height)
-		return ai
+		return &AnthropometricRatio{
+			Anthropometry: NewAnthropometry(weight, height),
+			lim:           lim,
+			prt:           prt(weight, height),
+			conf:          conf,
+			result:        result,
+		}
 	}
 }
 
@@ -205,7 +308,18 @@ var (
 )
 
 // List of validators for weight and height measures.
-var validators = []Validator{ValidateMeasures([]string{"weight", "height"})}
+var validators = []Validator{
+	ValidateMeasures([]string{"weight", "height"}),
+	func(e *Equation) (bool, error) {
+		if w, _ := e.In("weight"); w <= 0 {
+			return false, fmt.Errorf("Weight must be greater than zero")
+		}
+		if h, _ := e.In("height"); h <= 0 {
+			return false, fmt.Errorf("Height must be greater than zero")
+		}
+		return true, nil
+	},
+}
 
 // inParams method define base parameters for anthropometry. It receives an
 // interface, that must comply with Anthropometry struct and returns a map
@@ -269,3 +383,92 @@ var (
 		ObeseClassThree:         {1.6, math.Inf(+1)},
 	}
 )
+
+// IOTF classification constants, for children and adolescents.
+const (
+	IOTFThinness = iota
+	IOTFNormal
+	IOTFOverweight
+	IOTFObesity
+)
+
+// IOTFClassification map IOTF classification constants to their string
+// representation.
+var IOTFClassification = map[int]string{
+	IOTFThinness:   "Thinness",
+	IOTFNormal:     "Normal",
+	IOTFOverweight: "Overweight",
+	IOTFObesity:    "Obesity",
+}
+
+// iotfCutOffs represent the Cole IOTF cut-offs, by age in years, for thinness
+// grade 1, overweight and obesity, corresponding to an adult BMI of 18.5, 25
+// and 30.
+var iotfCutOffs = map[int][][4]float64{
+	Male: {
+		{2, 15.14, 18.41, 20.09},
+		{3, 14.74, 17.89, 19.57},
+		{4, 14.43, 17.55, 19.29},
+		{5, 14.21, 17.42, 19.30},
+		{6, 14.13, 17.55, 19.78},
+		{7, 14.21, 17.92, 20.63},
+		{8, 14.42, 18.44, 21.60},
+		{9, 14.78, 19.10, 22.77},
+		{10, 15.09, 19.84, 24.00},
+		{11, 15.53, 20.55, 25.10},
+		{12, 16.06, 21.22, 26.02},
+		{13, 16.62, 21.91, 26.84},
+		{14, 17.15, 22.62, 27.63},
+		{15, 17.62, 23.29, 28.30},
+		{16, 18.00, 23.90, 28.88},
+		{17, 18.28, 24.46, 29.41},
+		{18, 18.50, 25.00, 30.00},
+	},
+	Female: {
+		{2, 14.83, 18.02, 19.81},
+		{3, 14.47, 17.56, 19.36},
+		{4, 14.19, 17.28, 19.15},
+		{5, 14.00, 17.15, 19.17},
+		{6, 13.94, 17.34, 19.65},
+		{7, 14.08, 17.75, 20.51},
+		{8, 14.36, 18.35, 21.57},
+		{9, 14.79, 19.07, 22.81},
+		{10, 15.09, 19.86, 24.11},
+		{11, 15.62, 20.74, 25.42},
+		{12, 16.17, 21.68, 26.67},
+		{13, 16.70, 22.58, 27.76},
+		{14, 17.16, 23.34, 28.57},
+		{15, 17.54, 23.94, 29.11},
+		{16, 17.90, 24.37, 29.43},
+		{17, 18.21, 24.70, 29.69},
+		{18, 18.50, 25.00, 30.00},
+	},
+}
+
+// iotfLimits returns IOTF classification limits from Cole IOTF cut-offs, for a
+// gender and age in years, interpolated between whole years.
+func iotfLimits(gender int, age float64) (map[int][2]float64, error) {
+	rows, ok := iotfCutOffs[gender]
+	if !ok {
+		return nil, fmt.Errorf("No classification for gender %d", gender)
+	}
+	if age < rows[0][0] || age > rows[len(rows)-1][0] {
+		return nil, fmt.Errorf("No classification for age %.0f", age)
+	}
+
+	cutOffs := [3]float64{}
+	for i := range cutOffs {
+		table := make([][2]float64, len(rows))
+		for j, row := range rows {
+			table[j] = [2]float64{row[0], row[i+1]}
+		}
+		cutOffs[i] = interpolate(age, table)
+	}
+
+	return map[int][2]float64{
+		IOTFThinness:   {math.Inf(-1), cutOffs[0]},
+		IOTFNormal:     {cutOffs[0], cutOffs[1]},
+		IOTFOverweight: {cutOffs[1], cutOffs[2]},
+		IOTFObesity:    {cutOffs[2], math.Inf(+1)},
+	}, nil
+}
@@ -1,6 +1,7 @@
 package phass
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -126,3 +127,84 @@ type caseAnthropometry struct {
 }
 
 var FloatLimit = 0.0001
+
+func TestPersonBMI(t *testing.T) {
+	adult, _ := NewAssessment("2016-Jan-01")
+	birth, _ := NewAssessment("1978-Dec-15")
+	toddler, _ := NewAssessment("1980-Dec-15")
+	schoolBoy, _ := NewAssessment("1988-Dec-15")
+	teenGirl, _ := NewAssessment("2002-Mar-15")
+
+	cases := []struct {
+		person     *Person
+		assessment *Assessment
+		weight     float64
+		height     float64
+		method     int
+		classify   string
+	}{
+		{male, adult, 88.3, 173.5, BMIForAgeIOTF, BMIClassification[Overweight]},
+		{male, birth, 3.35, 50, BMIForAgeWHO, GrowthClassification[GrowthNormal]},
+		{male, toddler, 14.5, 85, BMIForAgeCDC, GrowthClassification[GrowthObese]},
+		{male, schoolBoy, 46, 138, BMIForAgeIOTF, IOTFClassification[IOTFObesity]},
+		{male, schoolBoy, 40, 138, BMIForAgeIOTF, IOTFClassification[IOTFOverweight]},
+		{male, schoolBoy, 27, 138, BMIForAgeIOTF, IOTFClassification[IOTFThinness]},
+		{female, teenGirl, 52, 160, BMIForAgeIOTF, IOTFClassification[IOTFNormal]},
+	}
+
+	for _, data := range cases {
+		bmi := NewPersonBMI(data.person, data.assessment, data.weight, data.height, data.method)
+		if classify, err := bmi.Classify(); err != nil || classify != data.classify {
+			t.Errorf("Classification defined is %s (%v) and expected is %s\n", classify, err, data.classify)
+		}
+		if _, err := bmi.Result(); err != nil {
+			t.Errorf("Unexpected result error: %s", err)
+		}
+	}
+
+	infant, _ := NewAssessment("1979-Jun-15")
+	if _, err := NewPersonBMI(male, infant, 9, 70, BMIForAgeIOTF).Classify(); err == nil {
+		t.Error("Expected error for IOTF classification under 2 years")
+	}
+	if _, err := NewPersonBMI(male, schoolBoy, 32.4, 138, 10).Classify(); err == nil {
+		t.Error("Expected error for unknown classification method")
+	}
+}
+
+func TestPersonBMIDefaultMethod(t *testing.T) {
+	birth, _ := NewAssessment("1988-Mar-15")
+
+	for _, bmi := range []*PersonBMI{
+		{Person: female, Assessment: birth, AnthropometricRatio: NewBMI(3.2, 49)},
+		NewPersonBMI(female, birth, 3.2, 49, BMIForAgeWHO),
+	} {
+		if c, err := bmi.Classify(); err != nil || c != GrowthClassification[GrowthNormal] {
+			t.Errorf("Default classification expected %s, got %s (%v)", GrowthClassification[GrowthNormal], c, err)
+		}
+	}
+}
+
+func TestBMIInstances(t *testing.T) {
+	first := NewBMI(70, 175)
+	second := NewBMI(90, 175)
+	if first == second || first.Weight != 70 {
+		t.Error("Expected BMI instances to be independent")
+	}
+}
+
+func TestBMIInvalid(t *testing.T) {
+	cases := []struct {
+		weight float64
+		height float64
+		err    string
+	}{
+		{0, 175, "Weight must be greater than zero"},
+		{70, 0, "Height must be greater than zero"},
+	}
+
+	for _, c := range cases {
+		if _, err := NewBMI(c.weight, c.height).Calc(); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("Expected error containing %q, got %v", c.err, err)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Arm anthropometry
+ */
+
+// ArmAnthropometry contains data needed to assess arm muscle and fat areas.
+// This is a composition of a person, assessment details, skinfolds (triceps),
+// circumferences and the arm circumference measured (CCFRightArm or
+// CCFLeftArm).
+type ArmAnthropometry struct {
+	*Person
+	*Assessment
+	*Skinfolds
+	*Circumferences
+	Arm int
+}
+
+// NewArmAnthropometry returns a new ArmAnthropometry instance, for the given
+// arm circumference constant.
+func NewArmAnthropometry(p *Person, a *Assessment, s *Skinfolds, c *Circumferences, arm int) *ArmAnthropometry {
+	return &ArmAnthropometry{p, a, s, c, arm}
+}
+
+func (a *ArmAnthropometry) String() string {
+	v, _ := a.MuscleArea()
+	c, _ := a.ClassifyMuscle()
+	return fmt.Sprintf("Arm muscle area: %.2f cm^2 (%s)", v, c)
+}
+
+// GetName returns this measurement name.
+func (a *ArmAnthropometry) GetName() string {
+	return "Arm anthropometry"
+}
+
+// Result returns arm muscle and fat indexes, with their classification.
+func (a *ArmAnthropometry) Result() ([]string, error) {
+	rs := []string{}
+
+	amc, err := a.MuscleCircumference()
+	if err != nil {
+		return rs, err
+	}
+
+	ama, _ := a.MuscleArea()
+	afa, _ := a.FatArea()
+	afi, _ := a.FatIndex()
+
+	cm, err := a.ClassifyMuscle()
+	if err != nil {
+		return rs, err
+	}
+	cf, _ := a.ClassifyFat()
+
+	rs = append(
+		rs,
+		fmt.Sprintf("Arm muscle circumference: %.2f cm.", amc),
+		fmt.Sprintf("Arm muscle area: %.2f cm^2.", ama),
+		fmt.Sprintf("Arm fat area: %.2f cm^2.", afa),
+		fmt.Sprintf("Arm fat index: %.2f%%.", afi),
+		fmt.Sprintf("Arm muscle area classification: %s.", cm),
+		fmt.Sprintf("Arm fat area classification: %s.", cf),
+	)
+	return rs, nil
+}
+
+// MuscleCircumference returns the arm muscle circumference, in cm.
+func (a *ArmAnthropometry) MuscleCircumference() (float64, error) {
+	return a.equation(armMuscleCircumferenceConf).Calc()
+}
+
+// MuscleArea returns the total arm muscle area, in cm^2, without the bone
+// area correction, as used by Frisancho references.
+func (a *ArmAnthropometry) MuscleArea() (float64, error) {
+	return a.equation(armMuscleAreaConf).Calc()
+}
+
+// FatArea returns the arm fat area, in cm^2.
+func (a *ArmAnthropometry) FatArea() (float64, error) {
+	return a.equation(armFatAreaConf).Calc()
+}
+
+// FatIndex returns the arm fat area as percentage of the total arm area.
+func (a *ArmAnthropometry) FatIndex() (float64, error) {
+	return a.equation(armFatIndexConf).Calc()
+}
+
+// Calc returns the arm muscle area, in cm^2.
+func (a *ArmAnthropometry) Calc() (float64, error) {
+	return a.MuscleArea()
+}
+
+// Classify returns the arm muscle area classification.
+func (a *ArmAnthropometry) Classify() (string, error) {
+	return a.ClassifyMuscle()
+}
+
+// ClassifyMuscle returns the arm muscle area classification, based in
+// Frisancho percentiles.
+func (a *ArmAnthropometry) ClassifyMuscle() (string, error) {
+	v, err := a.MuscleArea()
+	if err != nil {
+		return "", err
+	}
+	return a.classify(v, armMuscleAreaLimits, ArmMuscleClassification)
+}
+
+// ClassifyFat returns the arm fat area classification, based in Frisancho
+// percentiles.
+func (a *ArmAnthropometry) ClassifyFat() (string, error) {
+	v, err := a.FatArea()
+	if err != nil {
+		return "", err
+	}
+	return a.classify(v, armFatAreaLimits, ArmFatClassification)
+}
+
+// classify returns the classification for a value, given the age and gender
+// limits and the mapper.
+func (a *ArmAnthropometry) classify(v float64, table map[int]map[[2]float64]map[int][2]float64, mapper map[int]string) (string, error) {
+	limits, err := limitsForGenderAndAge(table, a.Person.Gender, a.Person.AgeFromDate(a.Assessment.Date))
+	if err != nil {
+		return "", err
+	}
+	return Classifier(v, limits, mapper), nil
+}
+
+// equation returns an equation, used to calculate an arm index.
+func (a *ArmAnthropometry) equation(conf *EquationConf) Equationer {
+	return NewEquation(conf.Extract(a), conf)
+}
+
+/**
+ * Equations
+ */
+
+// Equations for arm muscle and fat indexes, with triceps skinfold converted
+// from mm to cm.
+var (
+	armMuscleCircumferenceConf = newArmEquationConf(
+		"Arm muscle circumference",
+		func(c, t float64) float64 {
+			return c - math.Pi*t
+		},
+	)
+	armMuscleAreaConf = newArmEquationConf(
+		"Arm muscle area",
+		func(c, t float64) float64 {
+			return math.Pow(c-math.Pi*t, 2) / (4 * math.Pi)
+		},
+	)
+	armFatAreaConf = newArmEquationConf(
+		"Arm fat area",
+		func(c, t float64) float64 {
+			return math.Pow(c, 2)/(4*math.Pi) - math.Pow(c-math.Pi*t, 2)/(4*math.Pi)
+		},
+	)
+	armFatIndexConf = newArmEquationConf(
+		"Arm fat index",
+		func(c, t float64) float64 {
+			total := math.Pow(c, 2) / (4 * math.Pi)
+			return (total - math.Pow(c-math.Pi*t, 2)/(4*math.Pi)) / total * 100
+		},
+	)
+)
+
+// newArmEquationConf returns an equation configuration for an arm index,
+// calculated from arm circumference and triceps skinfold, both in cm.
+func newArmEquationConf(name string, calc func(float64, float64) float64) *EquationConf {
+	return NewEquationConf(
+		name,
+		func(i interface{}) InParams {
+			a := i.(*ArmAnthropometry)
+			r := map[string]float64{}
+			if v, ok := a.Skinfolds.Measures[SKFTriceps]; ok {
+				r[NamedSkinfold(SKFTriceps)] = v
+			}
+			if a.Arm == CCFRightArm || a.Arm == CCFLeftArm {
+				if v, ok := a.Circumferences.Measures[a.Arm]; ok {
+					r["arm"] = v
+				}
+			}
+			return r
+		},
+		[]Validator{
+			ValidateMeasures([]string{NamedSkinfold(SKFTriceps), "arm"}),
+			func(e *Equation) (bool, error) {
+				c, _ := e.In("arm")
+				t, _ := e.In(NamedSkinfold(SKFTriceps))
+				if c-math.Pi*t/10 <= 0 {
+					return false, fmt.Errorf("Arm circumference must be greater than triceps skinfold")
+				}
+				return true, nil
+			},
+		},
+		func(e *Equation) float64 {
+			c, _ := e.In("arm")
+			t, _ := e.In(NamedSkinfold(SKFTriceps))
+			return calc(c, t/10)
+		},
+	)
+}
+
+/**
+ * Classification
+ */
+
+// Frisancho percentile band constants.
+const (
+	FrisanchoBelow5 = iota
+	Frisancho5To15
+	Frisancho15To85
+	Frisancho85To95
+	FrisanchoAbove95
+)
+
+// ArmMuscleClassification map Frisancho percentile bands to arm muscle area
+// classification.
+var ArmMuscleClassification = map[int]string{
+	FrisanchoBelow5:  "Wasted",
+	Frisancho5To15:   "Below average",
+	Frisancho15To85:  "Average",
+	Frisancho85To95:  "Above average",
+	FrisanchoAbove95: "High muscle",
+}
+
+// ArmFatClassification map Frisancho percentile bands to arm fat area
+// classification.
+var ArmFatClassification = map[int]string{
+	FrisanchoBelow5:  "Lean",
+	Frisancho5To15:   "Below average",
+	Frisancho15To85:  "Average",
+	Frisancho85To95:  "Above average",
+	FrisanchoAbove95: "Excess fat",
+}
+
+// newFrisanchoLimits returns Frisancho percentile band limits, based in the
+// 5th, 15th, 85th and 95th percentiles.
+func newFrisanchoLimits(p5, p15, p85, p95 float64) map[int][2]float64 {
+	return map[int][2]float64{
+		FrisanchoBelow5:  {math.Inf(-1), p5},
+		Frisancho5To15:   {p5, p15},
+		Frisancho15To85:  {p15, math.Nextafter(p85, math.Inf(+1))},
+		Frisancho85To95:  {math.Nextafter(p85, math.Inf(+1)), math.Nextafter(p95, math.Inf(+1))},
+		FrisanchoAbove95: {math.Nextafter(p95, math.Inf(+1)), math.Inf(+1)},
+	}
+}
+
+// frisanchoRow returns Frisancho percentile band limits, in cm^2, from a
+// published table row, with the 5th, 10th, 25th, 50th, 75th, 90th and 95th
+// percentiles in mm^2. The 15th and 85th percentiles are not published, and
+// are linearly interpolated in the z-score scale between the nearest ones.
+func frisanchoRow(p5, p10, p25, p50, p75, p90, p95 float64) map[int][2]float64 {
+	p15 := p10 + (p25-p10)*(1.0364-1.2816)/(0.6745-1.2816)
+	p85 := p75 + (p90-p75)*(1.0364-0.6745)/(1.2816-0.6745)
+	return newFrisanchoLimits(p5/100, p15/100, p85/100, p95/100)
+}
+
+// armMuscleAreaLimits represent the arm muscle area percentiles from Frisancho
+// (1981), for one year age bands up to 19 years and decades for adults. The
+// reference uses total arm muscle area, with the bone area included, the same
+// variant calculated by MuscleArea.
+var armMuscleAreaLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{1, 2}:   frisanchoRow(956, 1014, 1133, 1278, 1447, 1644, 1720),
+		{2, 3}:   frisanchoRow(973, 1040, 1190, 1345, 1557, 1690, 1787),
+		{3, 4}:   frisanchoRow(1095, 1201, 1357, 1484, 1618, 1750, 1853),
+		{4, 5}:   frisanchoRow(1207, 1264, 1408, 1579, 1747, 1926, 2008),
+		{5, 6}:   frisanchoRow(1298, 1411, 1550, 1720, 1884, 2089, 2285),
+		{6, 7}:   frisanchoRow(1360, 1447, 1605, 1815, 2056, 2297, 2493),
+		{7, 8}:   frisanchoRow(1497, 1548, 1808, 2027, 2246, 2494, 2886),
+		{8, 9}:   frisanchoRow(1550, 1664, 1895, 2089, 2296, 2628, 2788),
+		{9, 10}:  frisanchoRow(1811, 1884, 2067, 2288, 2657, 3053, 3257),
+		{10, 11}: frisanchoRow(1930, 2027, 2182, 2575, 2903, 3486, 3882),
+		{11, 12}: frisanchoRow(2016, 2156, 2382, 2670, 3022, 3359, 4226),
+		{12, 13}: frisanchoRow(2216, 2339, 2649, 3022, 3496, 4081, 4573),
+		{13, 14}: frisanchoRow(2363, 2546, 3044, 3553, 4081, 4811, 5130),
+		{14, 15}: frisanchoRow(2830, 3147, 3586, 3963, 4575, 5134, 5760),
+		{15, 16}: frisanchoRow(3138, 3317, 4146, 4568, 5193, 5954, 6372),
+		{16, 17}: frisanchoRow(3625, 4044, 4460, 5183, 5812, 6624, 7096),
+		{17, 18}: frisanchoRow(3998, 4252, 4961, 5589, 6294, 7326, 8022),
+		{18, 19}: frisanchoRow(4070, 4481, 5079, 5785, 6487, 7461, 8123),
+		{19, 25}: frisanchoRow(4508, 4777, 5274, 5913, 6660, 7606, 8262),
+		{25, 35}: frisanchoRow(4694, 4963, 5541, 6214, 7067, 7847, 8436),
+		{35, 45}: frisanchoRow(4844, 5181, 5740, 6490, 7265, 8034, 8488),
+		{45, 55}: frisanchoRow(4546, 4946, 5589, 6297, 7142, 7918, 8458),
+		{55, 65}: frisanchoRow(4422, 4783, 5381, 6144, 6919, 7670, 8149),
+		{65, 75}: frisanchoRow(3973, 4411, 5031, 5716, 6432, 7074, 7453),
+	},
+	Female: {
+		{1, 2}:   frisanchoRow(885, 973, 1084, 1221, 1378, 1535, 1621),
+		{2, 3}:   frisanchoRow(901, 973, 1145, 1269, 1405, 1595, 1727),
+		{3, 4}:   frisanchoRow(1018, 1110, 1231, 1396, 1563, 1752, 1846),
+		{4, 5}:   frisanchoRow(1093, 1138, 1252, 1411, 1604, 1777, 1902),
+		{5, 6}:   frisanchoRow(1146, 1228, 1365, 1541, 1784, 1968, 2056),
+		{6, 7}:   frisanchoRow(1192, 1264, 1421, 1607, 1795, 2040, 2153),
+		{7, 8}:   frisanchoRow(1221, 1339, 1532, 1716, 1899, 2129, 2401),
+		{8, 9}:   frisanchoRow(1281, 1360, 1580, 1771, 2115, 2418, 2573),
+		{9, 10}:  frisanchoRow(1334, 1500, 1689, 1951, 2219, 2505, 2718),
+		{10, 11}: frisanchoRow(1415, 1566, 1800, 2048, 2389, 2881, 3128),
+		{11, 12}: frisanchoRow(1578, 1725, 1938, 2278, 2691, 3111, 3360),
+		{12, 13}: frisanchoRow(1812, 1990, 2310, 2735, 3021, 3402, 3662),
+		{13, 14}: frisanchoRow(1911, 2092, 2388, 2904, 3279, 3722, 3954),
+		{14, 15}: frisanchoRow(2020, 2225, 2515, 2986, 3366, 3754, 3959),
+		{15, 16}: frisanchoRow(2118, 2278, 2621, 2887, 3366, 3770, 3966),
+		{16, 17}: frisanchoRow(2162, 2346, 2610, 2957, 3478, 3852, 4159),
+		{17, 18}: frisanchoRow(2169, 2357, 2656, 2968, 3498, 4070, 4356),
+		{18, 19}: frisanchoRow(2084, 2322, 2627, 2923, 3449, 4054, 4326),
+		{19, 25}: frisanchoRow(2166, 2372, 2644, 2966, 3362, 3913, 4366),
+		{25, 35}: frisanchoRow(2145, 2371, 2727, 3060, 3581, 4117, 4475),
+		{35, 45}: frisanchoRow(2310, 2467, 2847, 3329, 3878, 4408, 4707),
+		{45, 55}: frisanchoRow(2231, 2554, 2982, 3369, 3787, 4444, 5175),
+		{55, 65}: frisanchoRow(2371, 2618, 3004, 3464, 4035, 4752, 5035),
+		{65, 75}: frisanchoRow(2347, 2588, 3007, 3513, 4022, 4769, 5033),
+	},
+}
+
+// armFatAreaLimits represent the arm fat area percentiles from Frisancho
+// (1981), for one year age bands up to 19 years and decades for adults.
+var armFatAreaLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{1, 2}:   frisanchoRow(452, 486, 590, 741, 895, 1036, 1176),
+		{2, 3}:   frisanchoRow(434, 504, 578, 737, 871, 1044, 1148),
+		{3, 4}:   frisanchoRow(464, 519, 590, 736, 868, 1071, 1151),
+		{4, 5}:   frisanchoRow(428, 494, 598, 722, 859, 989, 1085),
+		{5, 6}:   frisanchoRow(446, 488, 582, 713, 914, 1176, 1299),
+		{6, 7}:   frisanchoRow(371, 446, 539, 678, 896, 1115, 1519),
+		{7, 8}:   frisanchoRow(423, 473, 574, 758, 1011, 1393, 1511),
+		{8, 9}:   frisanchoRow(410, 460, 588, 725, 1003, 1248, 1558),
+		{9, 10}:  frisanchoRow(485, 527, 635, 859, 1252, 1864, 2081),
+		{10, 11}: frisanchoRow(523, 543, 738, 982, 1376, 1906, 2609),
+		{11, 12}: frisanchoRow(536, 595, 754, 1148, 1710, 2348, 2574),
+		{12, 13}: frisanchoRow(554, 650, 874, 1172, 1558, 2536, 3580),
+		{13, 14}: frisanchoRow(475, 570, 812, 1096, 1702, 2744, 3322),
+		{14, 15}: frisanchoRow(453, 563, 786, 1082, 1608, 2746, 3508),
+		{15, 16}: frisanchoRow(521, 595, 690, 931, 1423, 2434, 3100),
+		{16, 17}: frisanchoRow(542, 593, 844, 1078, 1746, 2280, 3041),
+		{17, 18}: frisanchoRow(598, 698, 827, 1096, 1636, 2407, 2888),
+		{18, 19}: frisanchoRow(560, 665, 860, 1264, 1947, 3302, 3928),
+		{19, 25}: frisanchoRow(594, 743, 963, 1406, 2231, 3098, 3652),
+		{25, 35}: frisanchoRow(675, 831, 1174, 1752, 2459, 3246, 3786),
+		{35, 45}: frisanchoRow(703, 851, 1310, 1849, 2558, 3382, 3769),
+		{45, 55}: frisanchoRow(749, 922, 1254, 1833, 2565, 3518, 3948),
+		{55, 65}: frisanchoRow(658, 839, 1231, 1700, 2406, 3356, 3752),
+		{65, 75}: frisanchoRow(573, 753, 1079, 1624, 2280, 3052, 3691),
+	},
+	Female: {
+		{1, 2}:   frisanchoRow(401, 466, 578, 706, 847, 1022, 1140),
+		{2, 3}:   frisanchoRow(469, 526, 642, 747, 894, 1061, 1173),
+		{3, 4}:   frisanchoRow(473, 529, 656, 822, 967, 1106, 1158),
+		{4, 5}:   frisanchoRow(490, 541, 654, 766, 907, 1109, 1236),
+		{5, 6}:   frisanchoRow(470, 529, 647, 812, 991, 1330, 1536),
+		{6, 7}:   frisanchoRow(464, 508, 638, 827, 1009, 1263, 1436),
+		{7, 8}:   frisanchoRow(491, 560, 706, 920, 1135, 1407, 1644),
+		{8, 9}:   frisanchoRow(527, 634, 769, 1042, 1383, 1872, 2482),
+		{9, 10}:  frisanchoRow(642, 690, 933, 1219, 1584, 2171, 2524),
+		{10, 11}: frisanchoRow(616, 702, 842, 1141, 1608, 2500, 3005),
+		{11, 12}: frisanchoRow(707, 802, 1015, 1301, 1942, 2730, 3690),
+		{12, 13}: frisanchoRow(782, 854, 1090, 1511, 2056, 2666, 3369),
+		{13, 14}: frisanchoRow(726, 838, 1219, 1625, 2374, 3272, 4150),
+		{14, 15}: frisanchoRow(981, 1043, 1423, 1818, 2403, 3250, 3765),
+		{15, 16}: frisanchoRow(839, 1126, 1396, 1886, 2544, 3093, 4195),
+		{16, 17}: frisanchoRow(1126, 1351, 1663, 2006, 2598, 3374, 4236),
+		{17, 18}: frisanchoRow(1042, 1267, 1463, 2104, 2977, 3864, 5159),
+		{18, 19}: frisanchoRow(1003, 1230, 1616, 2104, 2617, 3508, 3733),
+		{19, 25}: frisanchoRow(1046, 1198, 1596, 2166, 2959, 4050, 4896),
+		{25, 35}: frisanchoRow(1173, 1399, 1841, 2548, 3512, 4690, 5560),
+		{35, 45}: frisanchoRow(1336, 1619, 2158, 2898, 3932, 5093, 5847),
+		{45, 55}: frisanchoRow(1459, 1803, 2447, 3244, 4229, 5416, 6140),
+		{55, 65}: frisanchoRow(1345, 1879, 2520, 3369, 4360, 5276, 6152),
+		{65, 75}: frisanchoRow(1363, 1681, 2266, 3063, 3943, 4914, 5530),
+	},
+}
@@ -0,0 +1,109 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArmAnthropometry(t *testing.T) {
+	assessment, _ := NewAssessment("2016-Jan-01")
+
+	cases := []struct {
+		person      *Person
+		arm         int
+		circ        float64
+		triceps     float64
+		amc         float64
+		ama         float64
+		afa         float64
+		afi         float64
+		muscleClass int
+		fatClass    int
+	}{
+		{male, CCFRightArm, 32, 12, 28.2301, 63.4183, 18.069, 22.174, Frisancho15To85, Frisancho15To85},
+		{male, CCFLeftArm, 26, 8, 23.4867, 43.897, 9.8973, 18.3985, FrisanchoBelow5, Frisancho5To15},
+		{male, CCFRightArm, 24, 5, 22.4292, 40.033, 5.8037, 12.6616, FrisanchoBelow5, FrisanchoBelow5},
+		{female, CCFRightArm, 30, 25, 22.146, 39.0285, 32.5913, 45.506, Frisancho85To95, Frisancho15To85},
+	}
+
+	for _, c := range cases {
+		a := NewArmAnthropometry(
+			c.person,
+			assessment,
+			NewSkinfolds(map[int]float64{SKFTriceps: c.triceps}),
+			NewCircumferences(map[int]float64{c.arm: c.circ}),
+			c.arm,
+		)
+
+		if v, _ := a.MuscleCircumference(); !floatEqual(v, c.amc, 0.0001) {
+			t.Errorf("Arm muscle circumference expected %.4f, got %.4f", c.amc, v)
+		}
+		if v, _ := a.MuscleArea(); !floatEqual(v, c.ama, 0.0001) {
+			t.Errorf("Arm muscle area expected %.4f, got %.4f", c.ama, v)
+		}
+		if v, _ := a.FatArea(); !floatEqual(v, c.afa, 0.0001) {
+			t.Errorf("Arm fat area expected %.4f, got %.4f", c.afa, v)
+		}
+		if v, _ := a.FatIndex(); !floatEqual(v, c.afi, 0.0001) {
+			t.Errorf("Arm fat index expected %.4f, got %.4f", c.afi, v)
+		}
+		if v, _ := a.ClassifyMuscle(); v != ArmMuscleClassification[c.muscleClass] {
+			t.Errorf("Arm muscle classification expected %s, got %s", ArmMuscleClassification[c.muscleClass], v)
+		}
+		if v, _ := a.ClassifyFat(); v != ArmFatClassification[c.fatClass] {
+			t.Errorf("Arm fat classification expected %s, got %s", ArmFatClassification[c.fatClass], v)
+		}
+		if _, err := a.Result(); err != nil {
+			t.Errorf("Unexpected result error: %s", err)
+		}
+	}
+}
+
+func TestArmFrisanchoLimits(t *testing.T) {
+	cases := []struct {
+		table  map[int]map[[2]float64]map[int][2]float64
+		gender int
+		band   [2]float64
+		p5     float64
+		p95    float64
+	}{
+		// Frisancho (1981), arm muscle area, males 10.0-10.9 years.
+		{armMuscleAreaLimits, Male, [2]float64{10, 11}, 19.30, 38.82},
+		// Frisancho (1981), arm fat area, females 25.0-34.9 years.
+		{armFatAreaLimits, Female, [2]float64{25, 35}, 11.73, 55.60},
+	}
+
+	for _, c := range cases {
+		limits := c.table[c.gender][c.band]
+		if v := limits[FrisanchoBelow5][1]; !floatEqual(v, c.p5, 0.0001) {
+			t.Errorf("Band %v 5th percentile expected %.2f, got %.2f", c.band, c.p5, v)
+		}
+		if v := limits[Frisancho85To95][1]; !floatEqual(v, c.p95, 0.0001) {
+			t.Errorf("Band %v 95th percentile expected %.2f, got %.2f", c.band, c.p95, v)
+		}
+	}
+}
+
+func TestArmAnthropometryErrors(t *testing.T) {
+	assessment, _ := NewAssessment("2016-Jan-01")
+	elder, _ := NewAssessment("2060-Jan-01")
+
+	cases := []struct {
+		assessment *Assessment
+		arm        int
+		measures   map[int]float64
+		err        string
+	}{
+		{assessment, CCFRightArm, map[int]float64{CCFLeftArm: 30}, "Missing arm measure"},
+		{assessment, CCFWaist, map[int]float64{CCFWaist: 80}, "Missing arm measure"},
+		{assessment, CCFRightArm, map[int]float64{CCFRightArm: 3}, "Arm circumference must be greater than triceps skinfold"},
+		{elder, CCFRightArm, map[int]float64{CCFRightArm: 30}, "No classification for age"},
+	}
+
+	for _, c := range cases {
+		a := NewArmAnthropometry(male, c.assessment, NewSkinfolds(map[int]float64{SKFTriceps: 10}), NewCircumferences(c.measures), c.arm)
+		if _, err := a.Result(); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("Expected error containing %q, got %v", c.err, err)
+		}
+	}
+}
@@ -18,6 +18,32 @@ const (
 	Female
 )
 
+// Population constants, used to select equations developed for a given group.
+const (
+	PopulationHealthy int = iota
+	PopulationCardiac
+	PopulationAthlete
+)
+
+// Ethnicity constants, used by equations with race specific coefficients.
+// EthnicityUnknown is used when ethnicity was not declared.
+const (
+	EthnicityUnknown int = iota
+	EthnicityWhite
+	EthnicityAfricanAmerican
+	EthnicityAsian
+	EthnicityHispanic
+)
+
+// Pubertal stage constants, used by equations developed for a given
+// maturation level. PubertalStageUnknown is used when it was not assessed.
+const (
+	PubertalStageUnknown int = iota
+	PubertalStagePrepubescent
+	PubertalStagePubescent
+	PubertalStagePostpubescent
+)
+
 /**
  * Interfaces
  */
@@ -100,11 +126,17 @@ func (a *Assessment) AddMeasure(m Measurer) {
  * Person
  */
 
-// Person is the common information from the individual being measured
+// Person is the common information from the individual being measured. The
+// declared population defaults to healthy, while ethnicity and pubertal stage
+// are optional, and can be changed to select equations developed for a given
+// group.
 type Person struct {
-	FullName string
-	Birthday time.Time
-	Gender   int
+	FullName      string
+	Birthday      time.Time
+	Gender        int
+	Population    int
+	Ethnicity     int
+	PubertalStage int
 }
 
 // NewPerson creates a Person representation, for assessment purposes a person
@@ -167,6 +199,24 @@ func (p *Person) AgeInMonthsFromDate(t time.Time) float64 {
 	return elapsedFromDateIn(p.Birthday, t, secondsInMonth)
 }
 
+// InParamsFromDate returns this Person attributes as equation input
+// parameters, with age based in a given time. Ethnicity and pubertal stage
+// are available only when declared.
+func (p *Person) InParamsFromDate(t time.Time) InParams {
+	r := map[string]float64{
+		"gender":     float64(p.Gender),
+		"age":        p.AgeFromDate(t),
+		"population": float64(p.Population),
+	}
+	if p.Ethnicity != EthnicityUnknown {
+		r["ethnicity"] = float64(p.Ethnicity)
+	}
+	if p.PubertalStage != PubertalStageUnknown {
+		r["pubertal stage"] = float64(p.PubertalStage)
+	}
+	return r
+}
+
 // genderRepr convert the constant gender into string representation.
 func (p *Person) genderRepr() string {
 	choices := map[int]string{
@@ -176,6 +226,43 @@ func (p *Person) genderRepr() string {
 	return choices[p.Gender]
 }
 
+/**
+ * Population
+ */
+
+// NamedPopulation returns the name for a given population constant.
+func NamedPopulation(population int) string {
+	named := map[int]string{
+		PopulationHealthy: "healthy",
+		PopulationCardiac: "cardiac",
+		PopulationAthlete: "athlete",
+	}
+	return named[population]
+}
+
+// NamedEthnicity returns the name for a given ethnicity constant.
+func NamedEthnicity(ethnicity int) string {
+	named := map[int]string{
+		EthnicityUnknown:         "unknown",
+		EthnicityWhite:           "white",
+		EthnicityAfricanAmerican: "african american",
+		EthnicityAsian:           "asian",
+		EthnicityHispanic:        "hispanic",
+	}
+	return named[ethnicity]
+}
+
+// NamedPubertalStage returns the name for a given pubertal stage constant.
+func NamedPubertalStage(stage int) string {
+	named := map[int]string{
+		PubertalStageUnknown:       "unknown",
+		PubertalStagePrepubescent:  "prepubescent",
+		PubertalStagePubescent:     "pubescent",
+		PubertalStagePostpubescent: "postpubescent",
+	}
+	return named[stage]
+}
+
 /**
  * Private methods
  */
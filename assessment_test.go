@@ -51,6 +51,42 @@ func TestPersonAge(t *testing.T) {
 	}
 }
 
+func TestPersonInParams(t *testing.T) {
+	p, _ := NewPerson("Someone", "2004-Jan-01", Female)
+	in := p.InParamsFromDate(refDate)
+	if _, ok := in["ethnicity"]; ok {
+		t.Errorf("Ethnicity should not be available when unknown")
+	}
+	if _, ok := (&Person{}).InParamsFromDate(refDate)["ethnicity"]; ok {
+		t.Errorf("Ethnicity should not be available when not declared")
+	}
+	if _, ok := in["pubertal stage"]; ok {
+		t.Errorf("Pubertal stage should not be available when unknown")
+	}
+	if v := in["population"]; int(v) != PopulationHealthy {
+		t.Errorf("Population expected %s, got %s", NamedPopulation(PopulationHealthy), NamedPopulation(int(v)))
+	}
+
+	p.Population = PopulationAthlete
+	p.Ethnicity = EthnicityHispanic
+	p.PubertalStage = PubertalStagePubescent
+	in = p.InParamsFromDate(refDate)
+	expect := map[string]int{
+		"gender":         Female,
+		"population":     PopulationAthlete,
+		"ethnicity":      EthnicityHispanic,
+		"pubertal stage": PubertalStagePubescent,
+	}
+	for k, e := range expect {
+		if v, ok := in[k]; !ok || int(v) != e {
+			t.Errorf("Input %s expected %d, got %.0f", k, e, v)
+		}
+	}
+	if NamedPubertalStage(p.PubertalStage) != "pubescent" {
+		t.Errorf("Pubertal stage name expected pubescent, got %s", NamedPubertalStage(p.PubertalStage))
+	}
+}
+
 func TestAssessmentDate(t *testing.T) {
 	_, err := NewAssessment("1900-Dec-40")
 	if err == nil {
@@ -0,0 +1,426 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+/**
+ * BIA equations
+ */
+
+// Bioelectrical impedance equations to estimate fat-free mass.
+var (
+	NewKyleBIA       = FactoryBIA(confKyleBIA)
+	NewSunBIA        = FactoryBIA(confSunBIA)
+	NewHoutkooperBIA = FactoryBIA(confHoutkooperBIA)
+)
+
+/**
+ * BIA
+ */
+
+// BIA contains data needed to estimate body composition from bioelectrical
+// impedance analysis. This is a composition of a person, assessment details,
+// anthropometric data, resistance and reactance (in ohms), and the equation.
+// Phase angle (in degrees) and frequency (in kHz) are optional, and should be
+// left as zero when not measured.
+type BIA struct {
+	*Person
+	*Assessment
+	*Anthropometry
+	Resistance float64
+	Reactance  float64
+	PhaseAngle float64
+	Frequency  float64
+	*EquationConf
+	tbw Calculator
+}
+
+// FactoryBIA factory to create new body composition assessment by
+// bioelectrical impedance. It returns a function to create new BIA structs.
+func FactoryBIA(conf BIAEquationConf) func(*Person, *Assessment, *Anthropometry, float64, float64) *BIA {
+	c := NewEquationConfForBIA(conf)
+	return func(p *Person, a *Assessment, an *Anthropometry, resistance, reactance float64) *BIA {
+		return &BIA{
+			Person:        p,
+			Assessment:    a,
+			Anthropometry: an,
+			Resistance:    resistance,
+			Reactance:     reactance,
+			EquationConf:  c,
+			tbw:           conf.tbw,
+		}
+	}
+}
+
+func (b *BIA) String() string {
+	v, _ := b.Calc()
+	return fmt.Sprintf("Body fat: %.2f %%", v)
+}
+
+// GetName returns this measurement name.
+func (b *BIA) GetName() string {
+	return "Bioelectrical impedance"
+}
+
+// Result returns information about body composition assessed by
+// bioelectrical impedance.
+func (b *BIA) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := b.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	ffm, _ := b.FatFreeMass()
+	tbw, _ := b.TotalBodyWater()
+	pa, _ := b.Phase()
+	rs = append(
+		rs,
+		fmt.Sprintf("Phase angle: %.2f degrees", pa),
+		fmt.Sprintf("Fat-free mass: %.2f kg", ffm),
+		fmt.Sprintf("Total body water: %.2f L", tbw),
+		fmt.Sprintf("Body fat: %.2f %%", v),
+	)
+	return rs, nil
+}
+
+// Phase returns the phase angle, in degrees. When not measured, it's
+// calculated from resistance and reactance.
+func (b *BIA) Phase() (float64, error) {
+	if b.PhaseAngle != 0 {
+		return b.PhaseAngle, nil
+	}
+	if b.Resistance <= 0 || b.Reactance <= 0 {
+		return 0.0, fmt.Errorf("Resistance and reactance must be greater than zero")
+	}
+	return math.Atan(b.Reactance/b.Resistance) * 180 / math.Pi, nil
+}
+
+// Vector returns the bioimpedance vector analysis, with resistance and
+// reactance normalized by height, placed against the gender reference
+// tolerance ellipses.
+func (b *BIA) Vector() (*BIVA, error) {
+	if b.Anthropometry.Height <= 0 {
+		return nil, fmt.Errorf("Height must be greater than zero")
+	}
+	if b.Resistance <= 0 || b.Reactance <= 0 {
+		return nil, fmt.Errorf("Resistance and reactance must be greater than zero")
+	}
+	if age := b.Person.AgeFromDate(b.Assessment.Date); age < 18 {
+		return nil, fmt.Errorf("No BIVA reference for age %.0f", age)
+	}
+	ref, ok := bivaReference[b.Person.Gender]
+	if !ok {
+		return nil, fmt.Errorf("No BIVA reference for gender %d", b.Person.Gender)
+	}
+	return ref.place(b.Resistance/(b.Anthropometry.Height/100), b.Reactance/(b.Anthropometry.Height/100)), nil
+}
+
+// FatFreeMass returns the fat-free mass, in kg.
+func (b *BIA) FatFreeMass() (float64, error) {
+	return b.equation().Calc()
+}
+
+// TotalBodyWater returns the total body water, in L. When the equation does
+// not define its own estimate, fat-free mass hydration is assumed.
+func (b *BIA) TotalBodyWater() (float64, error) {
+	e := b.equation()
+	ffm, err := e.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	if b.tbw == nil {
+		return ffm * ffmHydration, nil
+	}
+	return b.tbw(e.(*Equation)), nil
+}
+
+// Calc returns the body fat percentage.
+func (b *BIA) Calc() (float64, error) {
+	ffm, err := b.FatFreeMass()
+	if err != nil {
+		return 0.0, err
+	}
+	return (b.Anthropometry.Weight - ffm) / b.Anthropometry.Weight * 100, nil
+}
+
+// equation returns an equation, used to estimate fat-free mass.
+func (b *BIA) equation() Equationer {
+	return NewEquation(b.EquationConf.Extract(b), b.EquationConf)
+}
+
+/**
+ * BIA conf definition
+ */
+
+// ffmHydration represents the fraction of water in fat-free mass.
+const ffmHydration = 0.732
+
+// Popular bioelectrical impedance equations, at 50 kHz, with height in cm,
+// weight in kg and resistance and reactance in ohms.
+var (
+	confKyleBIA = BIAEquationConf{
+		name:       "Kyle et al. BIA equation",
+		lowerAge:   20,
+		upperAge:   94,
+		validators: []Validator{validateImpedance("reactance")},
+		ffm: func(e *Equation) float64 {
+			gender, _ := e.In("gender")
+			w, _ := e.In("weight")
+			xc, _ := e.In("reactance")
+			sex := 0.0
+			if int(gender) == Male {
+				sex = 1.0
+			}
+			return -4.104 + 0.518*impedanceIndex(e) + 0.231*w + 0.130*xc + 4.229*sex
+		},
+	}
+	confSunBIA = BIAEquationConf{
+		name:     "Sun et al. BIA equation",
+		lowerAge: 12,
+		upperAge: 94,
+		ffm: func(e *Equation) float64 {
+			gender, _ := e.In("gender")
+			w, _ := e.In("weight")
+			r, _ := e.In("resistance")
+			if int(gender) == Male {
+				return -10.68 + 0.65*impedanceIndex(e) + 0.26*w + 0.02*r
+			}
+			return -9.53 + 0.69*impedanceIndex(e) + 0.17*w + 0.02*r
+		},
+		tbw: func(e *Equation) float64 {
+			gender, _ := e.In("gender")
+			w, _ := e.In("weight")
+			if int(gender) == Male {
+				return 1.20 + 0.45*impedanceIndex(e) + 0.18*w
+			}
+			return 3.75 + 0.45*impedanceIndex(e) + 0.11*w
+		},
+	}
+	confHoutkooperBIA = BIAEquationConf{
+		name:     "Houtkooper et al. BIA equation for children",
+		lowerAge: 10,
+		upperAge: 19,
+		ffm: func(e *Equation) float64 {
+			w, _ := e.In("weight")
+			return 0.61*impedanceIndex(e) + 0.25*w + 1.31
+		},
+	}
+)
+
+/**
+ * BIA equation conf
+ */
+
+// NewEquationConfForBIA returns an equation configuration based in provided
+// configuration.
+func NewEquationConfForBIA(conf BIAEquationConf) *EquationConf {
+	extractor := func(i interface{}) InParams {
+		b := i.(*BIA)
+		r := map[string]float64{
+			"gender":     float64(b.Gender),
+			"age":        b.AgeFromDate(b.Date),
+			"weight":     b.Anthropometry.Weight,
+			"height":     b.Anthropometry.Height,
+			"resistance": b.Resistance,
+			"reactance":  b.Reactance,
+		}
+		if b.Frequency != 0 {
+			r["frequency"] = b.Frequency
+		}
+		return r
+	}
+	validators := []Validator{
+		ValidateMeasures([]string{"gender", "age", "weight", "height", "resistance", "reactance"}),
+		ValidateAge(conf.lowerAge, conf.upperAge),
+		func(e *Equation) (bool, error) {
+			if w, _ := e.In("weight"); w <= 0 {
+				return false, fmt.Errorf("Weight must be greater than zero")
+			}
+			return true, nil
+		},
+		validateImpedance("resistance"),
+		validateFrequency(50),
+	}
+	validators = append(validators, conf.validators...)
+	return NewEquationConf(conf.name, extractor, validators, conf.ffm)
+}
+
+// BIAEquationConf common configuration for bioelectrical impedance equations.
+type BIAEquationConf struct {
+	name       string
+	lowerAge   float64
+	upperAge   float64
+	validators []Validator
+	ffm        Calculator
+	tbw        Calculator
+}
+
+// impedanceIndex returns height squared divided by resistance.
+func impedanceIndex(e *Equation) float64 {
+	h, _ := e.In("height")
+	r, _ := e.In("resistance")
+	return math.Pow(h, 2) / r
+}
+
+// validateImpedance ensure an impedance measure is greater than zero.
+func validateImpedance(name string) Validator {
+	return func(e *Equation) (bool, error) {
+		if v, _ := e.In(name); v <= 0 {
+			return false, fmt.Errorf("%s must be greater than zero", strings.ToUpper(name[:1])+name[1:])
+		}
+		return true, nil
+	}
+}
+
+// validateFrequency ensure the frequency, when informed, is the one used to
+// develop the equation.
+func validateFrequency(expect float64) Validator {
+	return func(e *Equation) (bool, error) {
+		if v, ok := e.In("frequency"); ok && v != expect {
+			return false, fmt.Errorf("Valid for frequency of %.0f kHz", expect)
+		}
+		return true, nil
+	}
+}
+
+/**
+ * BIVA
+ */
+
+// BIVA represents a bioimpedance vector, with resistance and reactance
+// normalized by height (in ohm/m), its Mahalanobis distance to the reference
+// mean, and the interpretation of its placement.
+type BIVA struct {
+	ResistanceHeight float64
+	ReactanceHeight  float64
+	Distance         float64
+	Ellipse          int
+	Hydration        int
+	CellMass         int
+}
+
+func (v *BIVA) String() string {
+	return fmt.Sprintf(
+		"R/H: %.1f ohm/m, Xc/H: %.1f ohm/m (%s; %s; %s)",
+		v.ResistanceHeight,
+		v.ReactanceHeight,
+		BIVAEllipseClassification[v.Ellipse],
+		HydrationClassification[v.Hydration],
+		CellMassClassification[v.CellMass],
+	)
+}
+
+// bivaRef represents the bivariate normal distribution of a reference
+// population, for R/H and Xc/H.
+type bivaRef struct {
+	meanR, sdR, meanXc, sdXc, r float64
+}
+
+// place returns the vector placement against this reference. Vectors inside
+// the 75% tolerance ellipse are considered normal. Outside it, displacement
+// along the major axis indicates hydration, and along the minor axis
+// indicates soft tissue cell mass, whichever is dominant.
+func (ref bivaRef) place(rh, xch float64) *BIVA {
+	zr := (rh - ref.meanR) / ref.sdR
+	zxc := (xch - ref.meanXc) / ref.sdXc
+	d2 := (math.Pow(zr, 2) - 2*ref.r*zr*zxc + math.Pow(zxc, 2)) / (1 - math.Pow(ref.r, 2))
+
+	v := &BIVA{
+		ResistanceHeight: rh,
+		ReactanceHeight:  xch,
+		Distance:         math.Sqrt(d2),
+		Ellipse:          classifierIndex(d2, bivaEllipseLimits),
+		Hydration:        HydrationNormal,
+		CellMass:         CellMassNormal,
+	}
+	if v.Ellipse == BIVAEllipse50 || v.Ellipse == BIVAEllipse75 {
+		return v
+	}
+
+	major := (zr + zxc) / math.Sqrt2
+	minor := (zxc - zr) / math.Sqrt2
+	switch {
+	case math.Abs(major) >= math.Abs(minor) && major > 0:
+		v.Hydration = HydrationDehydrated
+	case math.Abs(major) >= math.Abs(minor):
+		v.Hydration = HydrationOverhydrated
+	case minor > 0:
+		v.CellMass = CellMassHigh
+	default:
+		v.CellMass = CellMassLow
+	}
+	return v
+}
+
+// bivaReference represents R/H and Xc/H, in ohm/m, at 50 kHz, for healthy
+// adults, from Piccoli et al.
+var bivaReference = map[int]bivaRef{
+	Male:   {meanR: 298.6, sdR: 43.2, meanXc: 30.8, sdXc: 7.2, r: 0.60},
+	Female: {meanR: 371.9, sdR: 50.0, meanXc: 34.4, sdXc: 7.7, r: 0.62},
+}
+
+/**
+ * Classification
+ */
+
+// BIVA tolerance ellipse constants.
+const (
+	BIVAEllipse50 = iota
+	BIVAEllipse75
+	BIVAEllipse95
+	BIVAOutside95
+)
+
+// BIVAEllipseClassification map tolerance ellipse constants to their string
+// representation.
+var BIVAEllipseClassification = map[int]string{
+	BIVAEllipse50: "Inside 50% tolerance ellipse",
+	BIVAEllipse75: "Between 50% and 75% tolerance ellipses",
+	BIVAEllipse95: "Between 75% and 95% tolerance ellipses",
+	BIVAOutside95: "Outside 95% tolerance ellipse",
+}
+
+// Hydration constants.
+const (
+	HydrationNormal = iota
+	HydrationDehydrated
+	HydrationOverhydrated
+)
+
+// HydrationClassification map hydration constants to their string
+// representation.
+var HydrationClassification = map[int]string{
+	HydrationNormal:       "Normal hydration",
+	HydrationDehydrated:   "Dehydration",
+	HydrationOverhydrated: "Fluid overload",
+}
+
+// Cell mass constants.
+const (
+	CellMassNormal = iota
+	CellMassHigh
+	CellMassLow
+)
+
+// CellMassClassification map cell mass constants to their string
+// representation.
+var CellMassClassification = map[int]string{
+	CellMassNormal: "Normal cell mass",
+	CellMassHigh:   "More cell mass",
+	CellMassLow:    "Less cell mass",
+}
+
+// bivaEllipseLimits represent the squared Mahalanobis distance for 50%, 75%
+// and 95% tolerance ellipses, from the chi-square distribution with two
+// degrees of freedom.
+var bivaEllipseLimits = map[int][2]float64{
+	BIVAEllipse50: {0, -2 * math.Log(0.50)},
+	BIVAEllipse75: {-2 * math.Log(0.50), -2 * math.Log(0.25)},
+	BIVAEllipse95: {-2 * math.Log(0.25), -2 * math.Log(0.05)},
+	BIVAOutside95: {-2 * math.Log(0.05), math.Inf(+1)},
+}
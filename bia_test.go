@@ -0,0 +1,154 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBIA(t *testing.T) {
+	adult, _ := NewAssessment("2016-Jan-01")
+	teen, _ := NewAssessment("1992-Dec-20")
+
+	type bioimpedance struct {
+		weight     float64
+		height     float64
+		resistance float64
+		reactance  float64
+	}
+
+	cases := []struct {
+		factory    func(*Person, *Assessment, *Anthropometry, float64, float64) *BIA
+		person     *Person
+		assessment *Assessment
+		in         bioimpedance
+		ffm        float64
+		tbw        float64
+		fat        float64
+	}{
+		{NewKyleBIA, male, adult, bioimpedance{75, 178, 480, 60}, 59.4423, 43.5118, 20.7436},
+		{NewKyleBIA, female, adult, bioimpedance{60, 165, 580, 65}, 42.5207, 31.1252, 29.1321},
+		{NewSunBIA, male, adult, bioimpedance{75, 178, 480, 60}, 61.3254, 44.4038, 18.2328},
+		{NewSunBIA, female, adult, bioimpedance{60, 165, 580, 65}, 44.6584, 31.4728, 25.5694},
+		{NewHoutkooperBIA, male, teen, bioimpedance{45, 160, 600, 62}, 38.5867, 28.2454, 14.2519},
+	}
+
+	for _, c := range cases {
+		b := c.factory(c.person, c.assessment, NewAnthropometry(c.in.weight, c.in.height), c.in.resistance, c.in.reactance)
+		if v, err := b.FatFreeMass(); err != nil || !floatEqual(v, c.ffm, 0.0001) {
+			t.Errorf("%s fat-free mass expected %.4f, got %.4f (%v)", b.EquationConf.Name, c.ffm, v, err)
+		}
+		if v, _ := b.TotalBodyWater(); !floatEqual(v, c.tbw, 0.0001) {
+			t.Errorf("%s total body water expected %.4f, got %.4f", b.EquationConf.Name, c.tbw, v)
+		}
+		if v, _ := b.Calc(); !floatEqual(v, c.fat, 0.0001) {
+			t.Errorf("%s body fat expected %.4f, got %.4f", b.EquationConf.Name, c.fat, v)
+		}
+		if _, err := b.Result(); err != nil {
+			t.Errorf("Unexpected result error: %s", err)
+		}
+	}
+}
+
+func TestBIAErrors(t *testing.T) {
+	adult, _ := NewAssessment("2016-Jan-01")
+	nineteen, _ := NewAssessment("1998-Jun-01")
+	an := NewAnthropometry(75, 178)
+
+	withFrequency := NewKyleBIA(male, adult, an, 480, 60)
+	withFrequency.Frequency = 5
+
+	cases := []struct {
+		bia *BIA
+		err string
+	}{
+		{NewHoutkooperBIA(male, adult, an, 480, 60), "Valid for ages between 10 and 19"},
+		{NewKyleBIA(male, nineteen, an, 480, 60), "Valid for ages between 20 and 94"},
+		{NewKyleBIA(male, adult, an, 480, 0), "Reactance must be greater than zero"},
+		{NewKyleBIA(male, adult, NewAnthropometry(0, 178), 480, 60), "Weight must be greater than zero"},
+		{NewSunBIA(male, adult, an, 0, 60), "Resistance must be greater than zero"},
+		{withFrequency, "Valid for frequency of 50 kHz"},
+	}
+
+	for _, c := range cases {
+		if _, err := c.bia.Calc(); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("Expected error containing %q, got %v", c.err, err)
+		}
+		if _, err := c.bia.TotalBodyWater(); err == nil {
+			t.Errorf("Expected error for total body water")
+		}
+	}
+
+	fifty := NewKyleBIA(male, adult, an, 480, 60)
+	fifty.Frequency = 50
+	if _, err := fifty.Calc(); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestBIAPhase(t *testing.T) {
+	adult, _ := NewAssessment("2016-Jan-01")
+	an := NewAnthropometry(75, 178)
+
+	b := NewKyleBIA(male, adult, an, 530, 55)
+	if v, err := b.Phase(); err != nil || !floatEqual(v, 5.9246, 0.0001) {
+		t.Errorf("Phase angle expected 5.9246, got %.4f (%v)", v, err)
+	}
+
+	b.PhaseAngle = 6.5
+	if v, _ := b.Phase(); v != 6.5 {
+		t.Errorf("Phase angle expected measured 6.5, got %.4f", v)
+	}
+
+	if _, err := NewKyleBIA(male, adult, an, 530, 0).Phase(); err == nil || !strings.Contains(err.Error(), "Resistance and reactance must be greater than zero") {
+		t.Errorf("Expected error for missing reactance, got %v", err)
+	}
+}
+
+func TestBIAVector(t *testing.T) {
+	adult, _ := NewAssessment("2016-Jan-01")
+	an := NewAnthropometry(75, 178)
+
+	cases := []struct {
+		resistance float64
+		reactance  float64
+		rh         float64
+		xch        float64
+		distance   float64
+		ellipse    int
+		hydration  int
+		cellMass   int
+	}{
+		{530, 55, 297.7528, 30.8989, 0.0374, BIVAEllipse50, HydrationNormal, CellMassNormal},
+		{391.6, 35.6, 220.0, 20.0, 1.8897, BIVAEllipse95, HydrationOverhydrated, CellMassNormal},
+		{712, 80.1, 400.0, 45.0, 2.4508, BIVAOutside95, HydrationDehydrated, CellMassNormal},
+		{480.6, 80.1, 270.0, 45.0, 3.0349, BIVAOutside95, HydrationNormal, CellMassHigh},
+		{605.2, 35.6, 340.0, 20.0, 2.7651, BIVAOutside95, HydrationNormal, CellMassLow},
+	}
+
+	for _, c := range cases {
+		v, err := NewKyleBIA(male, adult, an, c.resistance, c.reactance).Vector()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !floatEqual(v.ResistanceHeight, c.rh, 0.0001) || !floatEqual(v.ReactanceHeight, c.xch, 0.0001) {
+			t.Errorf("Vector expected (%.4f, %.4f), got (%.4f, %.4f)", c.rh, c.xch, v.ResistanceHeight, v.ReactanceHeight)
+		}
+		if !floatEqual(v.Distance, c.distance, 0.0001) {
+			t.Errorf("Distance expected %.4f, got %.4f", c.distance, v.Distance)
+		}
+		if v.Ellipse != c.ellipse || v.Hydration != c.hydration || v.CellMass != c.cellMass {
+			t.Errorf("Expected %s, %s, %s, got %s", BIVAEllipseClassification[c.ellipse], HydrationClassification[c.hydration], CellMassClassification[c.cellMass], v)
+		}
+	}
+
+	teen, _ := NewAssessment("1992-Dec-20")
+	if _, err := NewHoutkooperBIA(male, teen, NewAnthropometry(45, 160), 600, 62).Vector(); err == nil || !strings.Contains(err.Error(), "No BIVA reference for age 14") {
+		t.Errorf("Expected age error, got %v", err)
+	}
+	if _, err := NewKyleBIA(male, adult, an, 530, 0).Vector(); err == nil || !strings.Contains(err.Error(), "Resistance and reactance must be greater than zero") {
+		t.Errorf("Expected error for missing reactance, got %v", err)
+	}
+	if _, err := NewKyleBIA(male, adult, NewAnthropometry(75, 0), 530, 55).Vector(); err == nil || !strings.Contains(err.Error(), "Height must be greater than zero") {
+		t.Errorf("Expected error for missing height, got %v", err)
+	}
+}
@@ -0,0 +1,380 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Constants
+ */
+
+// Blood pressure measurement arm constants.
+const (
+	// BPRightArm: measured in right arm.
+	BPRightArm int = iota
+	// BPLeftArm: measured in left arm.
+	BPLeftArm
+)
+
+// Blood pressure measurement position constants.
+const (
+	// BPSeated: measured in seated position.
+	BPSeated int = iota
+	// BPSupine: measured in supine position.
+	BPSupine
+	// BPStanding: measured in standing position.
+	BPStanding
+)
+
+/**
+ * Blood pressure
+ */
+
+// Reading represents a single blood pressure reading, with systolic and
+// diastolic pressure in mmHg.
+type Reading struct {
+	Systolic  float64
+	Diastolic float64
+}
+
+// BloodPressure represents a collection of blood pressure readings, made in a
+// given arm and position, for a person in an assessment. HeightPercentile is
+// the height-for-age percentile, required to classify children younger than
+// 13 years.
+type BloodPressure struct {
+	*Person
+	*Assessment
+	Arm              int
+	Position         int
+	Readings         []Reading
+	HeightPercentile float64
+}
+
+// NewBloodPressure creates a new blood pressure measurement, based in person,
+// assessment, arm and position used, and the readings made.
+func NewBloodPressure(person *Person, assessment *Assessment, arm, position int, readings ...Reading) *BloodPressure {
+	return &BloodPressure{
+		Person:     person,
+		Assessment: assessment,
+		Arm:        arm,
+		Position:   position,
+		Readings:   readings,
+	}
+}
+
+func (b *BloodPressure) String() string {
+	c, _ := b.Classify()
+	s, _ := b.Systolic()
+	d, _ := b.Diastolic()
+	return fmt.Sprintf("Blood pressure: %.0f/%.0f mmHg (%s)", s, d, c)
+}
+
+// GetName returns this measurement name.
+func (b *BloodPressure) GetName() string {
+	return "Blood pressure"
+}
+
+// Result returns relevant information about blood pressure measurement.
+func (b *BloodPressure) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := b.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	c, err := b.Classify()
+	if err != nil {
+		return rs, err
+	}
+
+	s, _ := b.Systolic()
+	d, _ := b.Diastolic()
+	pp, _ := b.PulsePressure()
+	rs = append(
+		rs,
+		fmt.Sprintf("Blood pressure: %.0f/%.0f mmHg (%d readings, %s, %s).", s, d, len(b.Readings), NamedArm(b.Arm), NamedPosition(b.Position)),
+		fmt.Sprintf("Mean arterial pressure: %.1f mmHg.", v),
+		fmt.Sprintf("Pulse pressure: %.0f mmHg.", pp),
+		fmt.Sprintf("Blood pressure classification: %s.", c),
+	)
+	return rs, nil
+}
+
+// Systolic returns the mean systolic pressure from all readings.
+func (b *BloodPressure) Systolic() (float64, error) {
+	return b.mean(func(r Reading) float64 { return r.Systolic })
+}
+
+// Diastolic returns the mean diastolic pressure from all readings.
+func (b *BloodPressure) Diastolic() (float64, error) {
+	return b.mean(func(r Reading) float64 { return r.Diastolic })
+}
+
+// PulsePressure returns the difference between systolic and diastolic
+// pressure.
+func (b *BloodPressure) PulsePressure() (float64, error) {
+	s, err := b.Systolic()
+	if err != nil {
+		return 0.0, err
+	}
+	d, _ := b.Diastolic()
+	return s - d, nil
+}
+
+// mean returns the mean of a given pressure from all readings, and an error
+// when no reading was made.
+func (b *BloodPressure) mean(pressure func(Reading) float64) (float64, error) {
+	if len(b.Readings) == 0 {
+		return 0.0, fmt.Errorf("Missing blood pressure readings")
+	}
+	accum := 0.0
+	for _, r := range b.Readings {
+		accum += pressure(r)
+	}
+	return accum / float64(len(b.Readings)), nil
+}
+
+// Calc returns the mean arterial pressure.
+func (b *BloodPressure) Calc() (float64, error) {
+	return b.equation().Calc()
+}
+
+// Classify returns the classification for this measurement. Adults are
+// classified with ACC/AHA 2017 categories, and adolescents from 13 years with
+// AAP 2017 static thresholds. Children are classified with a hybrid: AAP 2017
+// cut-points (90th percentile, 95th percentile and 95th percentile + 12 mmHg)
+// applied to percentiles estimated by the Fourth Report (2004) regression,
+// which was fitted on a population that still included overweight children,
+// so limits may be slightly higher than the AAP 2017 normative tables.
+func (b *BloodPressure) Classify() (string, error) {
+	if _, err := b.Calc(); err != nil {
+		return "", err
+	}
+
+	sbpLimits, dbpLimits, err := b.limits()
+	if err != nil {
+		return "", err
+	}
+
+	s, _ := b.Systolic()
+	d, _ := b.Diastolic()
+	cid := classifierIndex(s, sbpLimits)
+	if dcid := classifierIndex(d, dbpLimits); dcid > cid {
+		cid = dcid
+	}
+	return BPClassification[cid], nil
+}
+
+// limits returns systolic and diastolic classification limits for this
+// person age.
+func (b *BloodPressure) limits() (map[int][2]float64, map[int][2]float64, error) {
+	age := b.Person.AgeFromDate(b.Assessment.Date)
+	switch {
+	case age >= 18:
+		return bpAdultSystolicLimits, bpAdultDiastolicLimits, nil
+	case age >= 13:
+		return bpAdolescentSystolicLimits, bpAdolescentDiastolicLimits, nil
+	case age < 1:
+		return nil, nil, fmt.Errorf("No classification for age %.0f", age)
+	}
+
+	zht, err := NewEquation(bpChildConf.Extract(b), bpChildConf).Calc()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	coefs, ok := bpChildCoefficients[b.Person.Gender]
+	if !ok {
+		return nil, nil, fmt.Errorf("No classification for gender %d", b.Person.Gender)
+	}
+	return bpChildLimits(coefs[0], age, zht, 120, 130, 140),
+		bpChildLimits(coefs[1], age, zht, 80, 80, 90),
+		nil
+}
+
+// equation returns an equation, used to calculate mean arterial pressure.
+func (b *BloodPressure) equation() Equationer {
+	return NewEquation(bpConf.Extract(b), bpConf)
+}
+
+// NamedArm returns the name for a given arm constant.
+func NamedArm(arm int) string {
+	named := map[int]string{
+		BPRightArm: "right arm",
+		BPLeftArm:  "left arm",
+	}
+	return named[arm]
+}
+
+// NamedPosition returns the name for a given position constant.
+func NamedPosition(position int) string {
+	named := map[int]string{
+		BPSeated:   "seated",
+		BPSupine:   "supine",
+		BPStanding: "standing",
+	}
+	return named[position]
+}
+
+/**
+ * Equation
+ */
+
+var (
+	bpConf = NewEquationConf(
+		"Mean arterial pressure",
+		func(i interface{}) InParams {
+			b := i.(*BloodPressure)
+			r := map[string]float64{}
+			if s, err := b.Systolic(); err == nil {
+				r["systolic"] = s
+			}
+			if d, err := b.Diastolic(); err == nil {
+				r["diastolic"] = d
+			}
+			return r
+		},
+		[]Validator{
+			ValidateMeasures([]string{"systolic", "diastolic"}),
+			func(e *Equation) (bool, error) {
+				s, _ := e.In("systolic")
+				d, _ := e.In("diastolic")
+				if d >= s {
+					return false, fmt.Errorf("Diastolic must be lower than systolic")
+				}
+				return true, nil
+			},
+		},
+		func(e *Equation) float64 {
+			s, _ := e.In("systolic")
+			d, _ := e.In("diastolic")
+			return d + (s-d)/3
+		},
+	)
+	bpChildConf = NewEquationConf(
+		"Height z-score",
+		func(i interface{}) InParams {
+			b := i.(*BloodPressure)
+			r := map[string]float64{
+				"age":    b.Person.AgeFromDate(b.Assessment.Date),
+				"gender": float64(b.Person.Gender),
+			}
+			if b.HeightPercentile > 0 {
+				r["height percentile"] = b.HeightPercentile
+			}
+			return r
+		},
+		[]Validator{
+			ValidateMeasures([]string{"age", "gender", "height percentile"}),
+			ValidateAge(1, 17),
+			ValidateRange("height percentile", 1, 99),
+		},
+		func(e *Equation) float64 {
+			pct, _ := e.In("height percentile")
+			return math.Sqrt2 * math.Erfinv(2*pct/100-1)
+		},
+	)
+)
+
+/**
+ * Classification
+ */
+
+// Blood pressure classification constants.
+const (
+	BPNormal = iota
+	BPElevated
+	BPStageOne
+	BPStageTwo
+	BPCrisis
+)
+
+// BPClassification map classification constants to their string
+// representation.
+var BPClassification = map[int]string{
+	BPNormal:   "Normal",
+	BPElevated: "Elevated",
+	BPStageOne: "Stage 1 hypertension",
+	BPStageTwo: "Stage 2 hypertension",
+	BPCrisis:   "Hypertensive crisis",
+}
+
+// Mappers defining limits for each classification constant, for adults and
+// adolescents.
+var (
+	bpAdultSystolicLimits = map[int][2]float64{
+		BPNormal:   {math.Inf(-1), 120},
+		BPElevated: {120, 130},
+		BPStageOne: {130, 140},
+		BPStageTwo: {140, math.Nextafter(180, math.Inf(+1))},
+		BPCrisis:   {math.Nextafter(180, math.Inf(+1)), math.Inf(+1)},
+	}
+	bpAdultDiastolicLimits = map[int][2]float64{
+		BPNormal:   {math.Inf(-1), 80},
+		BPStageOne: {80, 90},
+		BPStageTwo: {90, math.Nextafter(120, math.Inf(+1))},
+		BPCrisis:   {math.Nextafter(120, math.Inf(+1)), math.Inf(+1)},
+	}
+	bpAdolescentSystolicLimits = map[int][2]float64{
+		BPNormal:   {math.Inf(-1), 120},
+		BPElevated: {120, 130},
+		BPStageOne: {130, 140},
+		BPStageTwo: {140, math.Inf(+1)},
+	}
+	bpAdolescentDiastolicLimits = map[int][2]float64{
+		BPNormal:   {math.Inf(-1), 80},
+		BPStageOne: {80, 90},
+		BPStageTwo: {90, math.Inf(+1)},
+	}
+)
+
+// bpChildLimits returns the classification limits for children, using the
+// AAP 2017 cut-points over the 90th and 95th percentiles for age and height
+// estimated with the Fourth Report (2004) regression, capped by the adolescent
+// thresholds, whichever is lower.
+func bpChildLimits(c bpCoefficients, age, zht, elevated, stageOne, stageTwo float64) map[int][2]float64 {
+	p90, p95 := c.percentile(age, zht, 1.2816), c.percentile(age, zht, 1.6449)
+	e := math.Min(p90, elevated)
+	s1 := math.Min(p95, stageOne)
+	s2 := math.Min(p95+12, stageTwo)
+	return map[int][2]float64{
+		BPNormal:   {math.Inf(-1), e},
+		BPElevated: {e, s1},
+		BPStageOne: {s1, s2},
+		BPStageTwo: {s2, math.Inf(+1)},
+	}
+}
+
+// bpCoefficients represents the regression coefficients, from the Fourth
+// Report on high blood pressure in children and adolescents, used to
+// estimate blood pressure percentiles for age and height.
+type bpCoefficients struct {
+	alpha float64
+	beta  [4]float64
+	gamma [4]float64
+	sigma float64
+}
+
+// percentile returns the blood pressure for a given age, height z-score and
+// percentile z-score.
+func (c bpCoefficients) percentile(age, zht, z float64) float64 {
+	mu := c.alpha
+	for j := 0; j < 4; j++ {
+		mu += c.beta[j]*math.Pow(age-10, float64(j+1)) + c.gamma[j]*math.Pow(zht, float64(j+1))
+	}
+	return mu + z*c.sigma
+}
+
+// bpChildCoefficients maps gender to systolic and diastolic coefficients.
+var bpChildCoefficients = map[int][2]bpCoefficients{
+	Male: {
+		{102.19768, [4]float64{1.82416, 0.12776, 0.00249, -0.00135}, [4]float64{2.73157, -0.19618, -0.04659, 0.00947}, 10.7128},
+		{61.01217, [4]float64{0.68314, -0.09835, 0.01711, 0.00045}, [4]float64{1.46993, -0.07849, -0.03144, 0.00967}, 11.6032},
+	},
+	Female: {
+		{102.01027, [4]float64{1.94397, 0.00598, -0.00789, -0.00059}, [4]float64{2.03526, 0.02534, -0.01884, 0.00121}, 10.4855},
+		{60.50510, [4]float64{1.01301, 0.01157, 0.00424, -0.00137}, [4]float64{1.16641, 0.12795, -0.03869, -0.00079}, 10.9573},
+	},
+}
@@ -0,0 +1,110 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBloodPressureCalc(t *testing.T) {
+	a, _ := NewAssessment("2015-May-22")
+	bp := NewBloodPressure(male, a, BPRightArm, BPSeated, Reading{130, 82}, Reading{120, 78}, Reading{110, 80})
+
+	if s, _ := bp.Systolic(); !floatEqual(s, 120, 0.001) {
+		t.Errorf("Systolic is %.2f, expected is %.2f", s, 120.0)
+	}
+	if d, _ := bp.Diastolic(); !floatEqual(d, 80, 0.001) {
+		t.Errorf("Diastolic is %.2f, expected is %.2f", d, 80.0)
+	}
+	if pp, _ := bp.PulsePressure(); !floatEqual(pp, 40, 0.001) {
+		t.Errorf("Pulse pressure is %.2f, expected is %.2f", pp, 40.0)
+	}
+	if v, err := bp.Calc(); err != nil {
+		t.Errorf("Should not get an error: %s", err)
+	} else if !floatEqual(v, 93.333, 0.001) {
+		t.Errorf("Mean arterial pressure is %.3f, expected is %.3f", v, 93.333)
+	}
+	if rs, err := bp.Result(); err != nil || len(rs) != 4 {
+		t.Errorf("Should have a result, instead got %v", err)
+	}
+
+	empty := NewBloodPressure(male, a, BPRightArm, BPSeated)
+	if _, err := empty.Systolic(); err == nil || !strings.Contains(err.Error(), "Missing blood pressure readings") {
+		t.Errorf("Systolic should show proper error message, got %v", err)
+	}
+	if _, err := empty.Diastolic(); err == nil {
+		t.Error("Diastolic should show an error without readings")
+	}
+	if _, err := empty.PulsePressure(); err == nil {
+		t.Error("Pulse pressure should show an error without readings")
+	}
+
+	errCases := []*BloodPressure{
+		NewBloodPressure(male, a, BPRightArm, BPSeated),
+		NewBloodPressure(male, a, BPRightArm, BPSeated, Reading{80, 120}),
+	}
+	for _, data := range errCases {
+		if _, err := data.Calc(); err == nil {
+			t.Error("Should not get a mean arterial pressure")
+		}
+		if _, err := data.Classify(); err == nil {
+			t.Error("Should not get a classification")
+		}
+	}
+}
+
+func TestBloodPressureClassify(t *testing.T) {
+	baby, _ := NewPerson("Baby Dubas", "2015-Jan-15", Female)
+	unknown, _ := NewPerson("Unknown Dubas", "1978-Dec-15", -1)
+
+	type bpCase struct {
+		person     *Person
+		assessment string
+		pct        float64
+		reading    Reading
+		classify   string
+		err        string
+	}
+
+	cases := []bpCase{
+		// adults
+		{person: male, assessment: "2015-May-22", reading: Reading{118, 76}, classify: BPClassification[BPNormal]},
+		{person: male, assessment: "2015-May-22", reading: Reading{125, 78}, classify: BPClassification[BPElevated]},
+		{person: male, assessment: "2015-May-22", reading: Reading{125, 85}, classify: BPClassification[BPStageOne]},
+		{person: male, assessment: "2015-May-22", reading: Reading{135, 70}, classify: BPClassification[BPStageOne]},
+		{person: female, assessment: "2015-May-22", reading: Reading{150, 85}, classify: BPClassification[BPStageTwo]},
+		{person: female, assessment: "2015-May-22", reading: Reading{180, 120}, classify: BPClassification[BPStageTwo]},
+		{person: female, assessment: "2015-May-22", reading: Reading{185, 100}, classify: BPClassification[BPCrisis]},
+		// adolescents
+		{person: male, assessment: "1993-Dec-15", reading: Reading{125, 75}, classify: BPClassification[BPElevated]},
+		{person: male, assessment: "1993-Dec-15", reading: Reading{185, 100}, classify: BPClassification[BPStageTwo]},
+		// children
+		{person: male, assessment: "1988-Dec-15", pct: 50, reading: Reading{110, 70}, classify: BPClassification[BPNormal]},
+		{person: male, assessment: "1988-Dec-15", pct: 50, reading: Reading{117, 70}, classify: BPClassification[BPElevated]},
+		{person: male, assessment: "1988-Dec-15", pct: 50, reading: Reading{121, 70}, classify: BPClassification[BPStageOne]},
+		{person: male, assessment: "1988-Dec-15", pct: 50, reading: Reading{133, 70}, classify: BPClassification[BPStageTwo]},
+		{person: male, assessment: "1988-Dec-15", pct: 50, reading: Reading{110, 77}, classify: BPClassification[BPElevated]},
+		{person: male, assessment: "1988-Dec-15", pct: 50, reading: Reading{110, 85}, classify: BPClassification[BPStageOne]},
+		{person: male, assessment: "1988-Dec-15", reading: Reading{110, 70}, err: "Missing height percentile"},
+		{person: baby, assessment: "2015-May-22", pct: 50, reading: Reading{90, 50}, err: "No classification for age"},
+		{person: unknown, assessment: "1988-Dec-15", pct: 50, reading: Reading{110, 70}, err: "No classification for gender -1"},
+	}
+
+	for _, data := range cases {
+		a, _ := NewAssessment(data.assessment)
+		bp := NewBloodPressure(data.person, a, BPLeftArm, BPSupine, data.reading)
+		bp.HeightPercentile = data.pct
+
+		c, err := bp.Classify()
+		if data.err != "" {
+			if err == nil || !strings.Contains(err.Error(), data.err) {
+				t.Errorf("Reading %v should show proper error message, got %v", data.reading, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Reading %v should not get an error: %s", data.reading, err)
+		} else if c != data.classify {
+			t.Errorf("Reading %v classify is %s, expected is %s", data.reading, c, data.classify)
+		}
+	}
+}
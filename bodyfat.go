@@ -3,6 +3,7 @@ package phass
 import (
 	"fmt"
 	"math"
+	"strings"
 )
 
 /**
@@ -13,10 +14,44 @@ import (
 var (
 	NewWomenSevenSKF = FactoryBodyCompositionSKF(confWomenSevenSKF)
 	NewWomenThreeSKF = FactoryBodyCompositionSKF(confWomenThreeSKF)
-	NewWomenTwoSKF   = FactoryBodyCompositionSKF(confWomenTwoSKF)
 	NewMenSevenSKF   = FactoryBodyCompositionSKF(confMenSevenSKF)
 	NewMenThreeSKF   = FactoryBodyCompositionSKF(confMenThreeSKF)
-	NewMenTwoSKF     = FactoryBodyCompositionSKF(confMenTwoSKF)
+)
+
+// Children and adolescents skinfold percentage of fat estimation, with
+// Slaughter et al. equation selected by available skinfolds, sum of
+// skinfolds, maturation and ethnicity.
+var (
+	NewWomenSlaughterSKF = FactoryBodyCompositionSKF(confWomenSlaughterSKF)
+	NewMenSlaughterSKF   = FactoryBodyCompositionSKF(confMenSlaughterSKF)
+)
+
+// Two skinfold percentage of fat estimation, kept as aliases of Slaughter et
+// al. equations, that use triceps and calf when subscapular is not available.
+var (
+	NewWomenTwoSKF = NewWomenSlaughterSKF
+	NewMenTwoSKF   = NewMenSlaughterSKF
+)
+
+// Regional skinfold percentage of fat estimation.
+var (
+	NewWomenGuedesSKF   = FactoryBodyCompositionSKF(confWomenGuedesSKF)
+	NewWomenPetroskiSKF = FactoryBodyCompositionSKF(confWomenPetroskiSKF)
+	NewMenGuedesSKF     = FactoryBodyCompositionSKF(confMenGuedesSKF)
+	NewMenPetroskiSKF   = FactoryBodyCompositionSKF(confMenPetroskiSKF)
+	NewMenLohmanSKF     = FactoryBodyCompositionSKF(confMenLohmanSKF)
+)
+
+// Athlete skinfold percentage of fat estimation.
+var (
+	NewWomenFaulknerSKF = FactoryBodyCompositionSKF(confWomenFaulknerSKF)
+	NewWomenEvansSKF    = FactoryBodyCompositionSKF(confWomenEvansSKF)
+	NewWomenThorlandSKF = FactoryBodyCompositionSKF(confWomenThorlandSKF)
+	NewMenFaulknerSKF   = FactoryBodyCompositionSKF(confMenFaulknerSKF)
+	NewMenWithersSKF    = FactoryBodyCompositionSKF(confMenWithersSKF)
+	NewMenEvansSKF      = FactoryBodyCompositionSKF(confMenEvansSKF)
+	NewMenReillySKF     = FactoryBodyCompositionSKF(confMenReillySKF)
+	NewMenThorlandSKF   = FactoryBodyCompositionSKF(confMenThorlandSKF)
 )
 
 /**
@@ -132,20 +167,6 @@ var (
 			return (5.01/d - 4.57) * 100
 		},
 	}
-	confWomenTwoSKF = SKFEquationConf{
-		name:     "Women two skinfold equation from Slaughter et al.",
-		gender:   Female,
-		lowerAge: 6,
-		upperAge: 17,
-		skinfolds: []int{
-			SKFTriceps,
-			SKFCalf,
-		},
-		equation: func(e *Equation) float64 {
-			sskf, _ := e.In("sskf")
-			return 0.735*sskf + 1.0
-		},
-	}
 	confMenSevenSKF = SKFEquationConf{
 		name:     "Men seven skinfold equation from Jackson, Pollock",
 		gender:   Male,
@@ -184,22 +205,369 @@ var (
 			return (4.95/d - 4.5) * 100.0
 		},
 	}
-	confMenTwoSKF = SKFEquationConf{
-		name:     "Men two skinfold equation from Slaughter et al.",
+)
+
+// Regional and athlete skinfold equations to estimate body fat.
+var (
+	confWomenGuedesSKF = SKFEquationConf{
+		name:     "Women three skinfold equation from Guedes",
+		gender:   Female,
+		lowerAge: 17,
+		upperAge: 29,
+		skinfolds: []int{
+			SKFSubscapular,
+			SKFSuprailiac,
+			SKFThigh,
+		},
+		equation: func(e *Equation) float64 {
+			sskf, _ := e.In("sskf")
+			d := 1.16650 - 0.07063*math.Log10(sskf)
+			return (4.95/d - 4.5) * 100
+		},
+	}
+	confWomenPetroskiSKF = SKFEquationConf{
+		name:     "Women four skinfold equation from Petroski",
+		gender:   Female,
+		lowerAge: 18,
+		upperAge: 51,
+		skinfolds: []int{
+			SKFMidaxillary,
+			SKFSuprailiac,
+			SKFThigh,
+			SKFCalf,
+		},
+		equation: func(e *Equation) float64 {
+			age, _ := e.In("age")
+			sskf, _ := e.In("sskf")
+			d := 1.19547130 - 0.07513507*math.Log10(sskf) - 0.00041072*age
+			return (4.95/d - 4.5) * 100
+		},
+	}
+	confWomenFaulknerSKF = SKFEquationConf{
+		name:       "Women four skinfold equation from Faulkner",
+		gender:     Female,
+		population: PopulationAthlete,
+		lowerAge:   18,
+		upperAge:   55,
+		skinfolds:  faulknerSkinfolds,
+		equation:   faulknerEquation,
+	}
+	confMenGuedesSKF = SKFEquationConf{
+		name:     "Men three skinfold equation from Guedes",
+		gender:   Male,
+		lowerAge: 17,
+		upperAge: 27,
+		skinfolds: []int{
+			SKFTriceps,
+			SKFSuprailiac,
+			SKFAbdominal,
+		},
+		equation: func(e *Equation) float64 {
+			sskf, _ := e.In("sskf")
+			d := 1.17136 - 0.06706*math.Log10(sskf)
+			return (4.95/d - 4.5) * 100
+		},
+	}
+	confMenPetroskiSKF = SKFEquationConf{
+		name:     "Men four skinfold equation from Petroski",
+		gender:   Male,
+		lowerAge: 18,
+		upperAge: 66,
+		skinfolds: []int{
+			SKFSubscapular,
+			SKFTriceps,
+			SKFSuprailiac,
+			SKFCalf,
+		},
+		equation: func(e *Equation) float64 {
+			age, _ := e.In("age")
+			sskf, _ := e.In("sskf")
+			d := 1.10726863 - 0.00081201*sskf + 0.00000212*math.Pow(sskf, 2) - 0.00041761*age
+			return (4.95/d - 4.5) * 100
+		},
+	}
+	confMenFaulknerSKF = SKFEquationConf{
+		name:       "Men four skinfold equation from Faulkner",
+		gender:     Male,
+		population: PopulationAthlete,
+		lowerAge:   18,
+		upperAge:   55,
+		skinfolds:  faulknerSkinfolds,
+		equation:   faulknerEquation,
+	}
+	confMenLohmanSKF = SKFEquationConf{
+		name:     "Men three skinfold equation from Lohman",
+		gender:   Male,
+		lowerAge: 18,
+		upperAge: 55,
+		skinfolds: []int{
+			SKFSubscapular,
+			SKFTriceps,
+			SKFAbdominal,
+		},
+		equation: func(e *Equation) float64 {
+			sskf, _ := e.In("sskf")
+			d := 1.0982 - 0.000815*sskf + 0.00000084*math.Pow(sskf, 2)
+			return (4.95/d - 4.5) * 100
+		},
+	}
+)
+
+// faulknerSkinfolds represents sites used by Faulkner equation, the same for
+// men and women.
+var faulknerSkinfolds = []int{
+	SKFSubscapular,
+	SKFTriceps,
+	SKFSuprailiac,
+	SKFAbdominal,
+}
+
+// faulknerEquation estimates body fat percentage from Faulkner four
+// skinfolds, common to men and women. It was developed with competitive
+// swimmers, hence it is only valid for athletes.
+func faulknerEquation(e *Equation) float64 {
+	sskf, _ := e.In("sskf")
+	return 0.153*sskf + 5.783
+}
+
+// slaughterAlternatives represents sites summed with triceps by Slaughter et
+// al. equations, subscapular is preferred over calf.
+var slaughterAlternatives = []int{
+	SKFSubscapular,
+	SKFCalf,
+}
+
+// Slaughter et al. family of equations, for children and adolescents. When
+// subscapular skinfold is available triceps and subscapular equations are
+// used, otherwise triceps and calf equation.
+var (
+	confWomenSlaughterSKF = SKFEquationConf{
+		name:     "Women Slaughter et al. skinfold equations",
+		gender:   Female,
+		lowerAge: 6,
+		upperAge: 17,
+		skinfolds: []int{
+			SKFTriceps,
+		},
+		alternatives: slaughterAlternatives,
+		validators: []Validator{
+			validateSlaughter,
+		},
+		equation: slaughterEquation,
+	}
+	confMenSlaughterSKF = SKFEquationConf{
+		name:     "Men Slaughter et al. skinfold equations",
 		gender:   Male,
 		lowerAge: 6,
 		upperAge: 17,
 		skinfolds: []int{
 			SKFTriceps,
+		},
+		alternatives: slaughterAlternatives,
+		validators: []Validator{
+			validateSlaughter,
+		},
+		equation: slaughterEquation,
+	}
+)
+
+// slaughterEquation estimates body fat percentage from Slaughter et al.
+// equations. For triceps and subscapular sum up to 35 mm, boys intercept
+// depends on maturation and ethnicity.
+func slaughterEquation(e *Equation) float64 {
+	gender, _ := e.In("gender")
+	sum, _ := e.In("sskf")
+	if _, ok := e.In(NamedSkinfold(SKFSubscapular)); !ok {
+		return slaughterTricepsCalf(int(gender), sum)
+	}
+
+	switch {
+	case sum > 35 && int(gender) == Male:
+		return 0.783*sum + 1.6
+	case sum > 35:
+		return 0.546*sum + 9.7
+	case int(gender) == Male:
+		stage, _ := e.In("pubertal stage")
+		ethnicity, _ := e.In("ethnicity")
+		return 1.21*sum - 0.008*math.Pow(sum, 2) + slaughterIntercept[int(ethnicity)][int(stage)]
+	default:
+		return 1.33*sum - 0.013*math.Pow(sum, 2) - 2.5
+	}
+}
+
+// slaughterTricepsCalf estimates body fat percentage from Slaughter et al.
+// triceps and calf equation, for a given gender.
+func slaughterTricepsCalf(gender int, sum float64) float64 {
+	if gender == Male {
+		return 0.735*sum + 1.0
+	}
+	return 0.610*sum + 5.1
+}
+
+// validateSlaughter ensure, for boys with triceps and subscapular sum up to
+// 35 mm, that pubertal stage and ethnicity are known.
+func validateSlaughter(e *Equation) (bool, error) {
+	if _, ok := e.In(NamedSkinfold(SKFSubscapular)); !ok {
+		return true, nil
+	}
+	if gender, _ := e.In("gender"); int(gender) != Male {
+		return true, nil
+	}
+	if sum, _ := e.In("sskf"); sum > 35 {
+		return true, nil
+	}
+	for _, v := range slaughterValidators {
+		if ok, err := v(e); !ok {
+			return ok, err
+		}
+	}
+	return true, nil
+}
+
+// slaughterValidators ensure pubertal stage and ethnicity have a boys
+// intercept for triceps and subscapular equation.
+var slaughterValidators = []Validator{
+	ValidatePubertalStage(PubertalStagePrepubescent, PubertalStagePubescent, PubertalStagePostpubescent),
+	ValidateEthnicity(EthnicityWhite, EthnicityAfricanAmerican),
+}
+
+// slaughterIntercept represents boys intercept for triceps and subscapular
+// equation, by ethnicity and pubertal stage.
+var slaughterIntercept = map[int]map[int]float64{
+	EthnicityWhite: {
+		PubertalStagePrepubescent:  -1.7,
+		PubertalStagePubescent:     -3.4,
+		PubertalStagePostpubescent: -5.5,
+	},
+	EthnicityAfricanAmerican: {
+		PubertalStagePrepubescent:  -3.2,
+		PubertalStagePubescent:     -5.2,
+		PubertalStagePostpubescent: -6.8,
+	},
+}
+
+// Athlete skinfold equations to estimate body fat, valid only for persons
+// declared as athletes.
+var (
+	confWomenEvansSKF = SKFEquationConf{
+		name:       "Women collegiate athletes three skinfold equation from Evans et al.",
+		gender:     Female,
+		population: PopulationAthlete,
+		lowerAge:   18,
+		upperAge:   30,
+		skinfolds:  evansSkinfolds,
+		equation:   evansEquation,
+	}
+	confWomenThorlandSKF = SKFEquationConf{
+		name:       "Women adolescent athletes three skinfold equation from Thorland et al.",
+		gender:     Female,
+		population: PopulationAthlete,
+		lowerAge:   14,
+		upperAge:   19,
+		skinfolds: []int{
+			SKFSubscapular,
+			SKFTriceps,
+			SKFSuprailiac,
+		},
+		equation: func(e *Equation) float64 {
+			sskf, _ := e.In("sskf")
+			d := 1.0987 - 0.00122*sskf + 0.00000263*math.Pow(sskf, 2)
+			return (4.95/d - 4.5) * 100
+		},
+	}
+	confMenWithersSKF = SKFEquationConf{
+		name:       "Men athletes seven skinfold equation from Withers et al.",
+		gender:     Male,
+		population: PopulationAthlete,
+		lowerAge:   18,
+		upperAge:   40,
+		skinfolds: []int{
+			SKFSubscapular,
+			SKFTriceps,
+			SKFBiceps,
+			SKFSupraspinale,
+			SKFAbdominal,
+			SKFThigh,
 			SKFCalf,
 		},
 		equation: func(e *Equation) float64 {
 			sskf, _ := e.In("sskf")
-			return 0.610*sskf + 5.1
+			d := 1.0988 - 0.0004*sskf
+			return (4.95/d - 4.5) * 100
+		},
+	}
+	confMenEvansSKF = SKFEquationConf{
+		name:       "Men collegiate athletes three skinfold equation from Evans et al.",
+		gender:     Male,
+		population: PopulationAthlete,
+		lowerAge:   18,
+		upperAge:   30,
+		skinfolds:  evansSkinfolds,
+		equation:   evansEquation,
+	}
+	confMenReillySKF = SKFEquationConf{
+		name:       "Men soccer players four skinfold equation from Reilly et al.",
+		gender:     Male,
+		population: PopulationAthlete,
+		lowerAge:   18,
+		upperAge:   37,
+		skinfolds: []int{
+			SKFTriceps,
+			SKFAbdominal,
+			SKFThigh,
+			SKFCalf,
+		},
+		equation: func(e *Equation) float64 {
+			triceps, _ := e.In(NamedSkinfold(SKFTriceps))
+			abdominal, _ := e.In(NamedSkinfold(SKFAbdominal))
+			thigh, _ := e.In(NamedSkinfold(SKFThigh))
+			calf, _ := e.In(NamedSkinfold(SKFCalf))
+			return 5.174 + 0.124*thigh + 0.147*abdominal + 0.196*triceps + 0.130*calf
+		},
+	}
+	confMenThorlandSKF = SKFEquationConf{
+		name:       "Men adolescent athletes three skinfold equation from Thorland et al.",
+		gender:     Male,
+		population: PopulationAthlete,
+		lowerAge:   14,
+		upperAge:   19,
+		skinfolds: []int{
+			SKFSubscapular,
+			SKFTriceps,
+			SKFMidaxillary,
+		},
+		equation: func(e *Equation) float64 {
+			sskf, _ := e.In("sskf")
+			d := 1.1136 - 0.00154*sskf + 0.00000516*math.Pow(sskf, 2)
+			return (4.95/d - 4.5) * 100
 		},
 	}
 )
 
+// evansSkinfolds represents sites used by Evans et al. equation, the same for
+// men and women.
+var evansSkinfolds = []int{
+	SKFTriceps,
+	SKFAbdominal,
+	SKFThigh,
+}
+
+// evansEquation estimates body fat percentage from Evans et al. three
+// skinfolds, with gender and race coefficients. Race coefficient is applied
+// for african american persons.
+func evansEquation(e *Equation) float64 {
+	sskf, _ := e.In("sskf")
+	gender, _ := e.In("gender")
+	sex, race := 0.0, 0.0
+	if int(gender) == Male {
+		sex = 1.0
+	}
+	if v, ok := e.In("ethnicity"); ok && int(v) == EthnicityAfricanAmerican {
+		race = 1.0
+	}
+	return 8.997 + 0.2468*sskf - 6.343*sex - 1.998*race
+}
+
 /**
  * SKF equation conf
  */
@@ -209,14 +577,18 @@ var (
 func NewEquationConfForSKF(conf SKFEquationConf) *EquationConf {
 	extractor := func(i interface{}) InParams {
 		c := i.(*BodyCompositionSKF)
-		r := map[string]float64{
-			"gender": float64(c.Gender),
-			"age":    c.AgeFromDate(c.Date),
-			"sskf":   c.SumSpecific(conf.skinfolds),
+		r := c.Person.InParamsFromDate(c.Date)
+		r["sskf"] = c.SumSpecific(conf.skinfolds)
+		for _, k := range conf.skinfolds {
+			if v, ok := c.Skinfolds.Measures[k]; ok {
+				r[NamedSkinfold(k)] = v
+			}
 		}
-		for _, s := range conf.skinfolds {
-			if v, ok := c.Skinfolds.Measures[s]; ok {
-				r[NamedSkinfold(s)] = v
+		for _, k := range conf.alternatives {
+			if v, ok := c.Skinfolds.Measures[k]; ok {
+				r[NamedSkinfold(k)] = v
+				r["sskf"] += v
+				break
 			}
 		}
 		return r
@@ -227,17 +599,32 @@ func NewEquationConfForSKF(conf SKFEquationConf) *EquationConf {
 		ValidateAge(conf.lowerAge, conf.upperAge),
 		validateSkinfolds(conf.skinfolds),
 	}
+	if len(conf.alternatives) > 0 {
+		validators = append(validators, validateAlternativeSkinfolds(conf.alternatives))
+	}
+	if conf.population != PopulationHealthy {
+		validators = append(validators, ValidatePopulation(conf.population))
+	}
+	validators = append(validators, conf.validators...)
 	return NewEquationConf(conf.name, extractor, validators, conf.equation)
 }
 
-// SKFEquationConf common configuration for skinfold equations.
+// SKFEquationConf common configuration for skinfold equations. Equations
+// developed for a specific population are only valid for persons declared in
+// that population, while equations for the healthy population are valid for
+// everyone. Alternatives are sites, in order of preference, where the first
+// one available is added to the required skinfolds. Additional validators can
+// be used by equations with branches selected by other inputs.
 type SKFEquationConf struct {
-	name      string
-	gender    int
-	lowerAge  float64
-	upperAge  float64
-	skinfolds []int
-	equation  Calculator
+	name         string
+	gender       int
+	population   int
+	lowerAge     float64
+	upperAge     float64
+	skinfolds    []int
+	alternatives []int
+	validators   []Validator
+	equation     Calculator
 }
 
 func validateSkinfolds(skfs []int) Validator {
@@ -250,3 +637,18 @@ func validateSkinfolds(skfs []int) Validator {
 		return true, nil
 	}
 }
+
+// validateAlternativeSkinfolds ensure that one of the alternative sites is
+// available.
+func validateAlternativeSkinfolds(skfs []int) Validator {
+	return func(e *Equation) (bool, error) {
+		names := []string{}
+		for _, k := range skfs {
+			if _, ok := e.In(NamedSkinfold(k)); ok {
+				return true, nil
+			}
+			names = append(names, NamedSkinfold(k))
+		}
+		return false, fmt.Errorf("Missing skinfold %s", strings.Join(names, " or "))
+	}
+}
@@ -160,26 +160,26 @@ func TestFemaleThreeSkinfoldEquation(t *testing.T) {
 
 func TestFemaleTwoSkinfoldEquation(t *testing.T) {
 	cases := []caseBodyFat{
-		newCaseBodyFat(female, "2004-Mar-15", map[int]float64{SKFSubscapular: 28, SKFTriceps: 13.1, SKFChest: 7.5, SKFMidaxillary: 7.5, SKFSuprailiac: 30, SKFAbdominal: 18.5, SKFThigh: 31.1, SKFCalf: 14.5}, "for-age-16", 21.286, ""),
-		newCaseBodyFat(female, "2004-Mar-15", map[int]float64{SKFSubscapular: 18.5, SKFTriceps: 9.8, SKFChest: 9.6, SKFMidaxillary: 7.7, SKFSuprailiac: 21.1, SKFAbdominal: 34.1, SKFThigh: 20.8, SKFCalf: 20.6}, "for-age-16", 23.344, ""),
-		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFSubscapular: 27.2, SKFTriceps: 16.1, SKFChest: 11.9, SKFMidaxillary: 6.8, SKFSuprailiac: 36.5, SKFAbdominal: 45.4, SKFThigh: 34.6, SKFCalf: 10.4}, "for-age-7", 20.478, ""),
-		newCaseBodyFat(female, "2001-Mar-15", map[int]float64{SKFSubscapular: 21.7, SKFTriceps: 12.3, SKFChest: 12.8, SKFMidaxillary: 11.9, SKFSuprailiac: 36.9, SKFAbdominal: 32.7, SKFThigh: 27.9, SKFCalf: 17.4}, "for-age-13", 22.83, ""),
-		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFSubscapular: 32.5, SKFTriceps: 13.9, SKFChest: 8.2, SKFMidaxillary: 8.5, SKFSuprailiac: 30.8, SKFAbdominal: 25.1, SKFThigh: 13.7, SKFCalf: 20.1}, "for-age-7", 25.99, ""),
-		newCaseBodyFat(female, "2003-Mar-15", map[int]float64{SKFSubscapular: 21.3, SKFTriceps: 11.5, SKFChest: 8.2, SKFMidaxillary: 12.8, SKFSuprailiac: 31.9, SKFAbdominal: 20.1, SKFThigh: 31.2, SKFCalf: 16.8}, "for-age-15", 21.801, ""),
-		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFSubscapular: 35.4, SKFTriceps: 13.7, SKFChest: 6.5, SKFMidaxillary: 11.4, SKFSuprailiac: 38.6, SKFAbdominal: 29.3, SKFThigh: 27.6, SKFCalf: 20.7}, "for-age-7", 26.284, ""),
-		newCaseBodyFat(female, "2004-Mar-15", map[int]float64{SKFSubscapular: 30.5, SKFTriceps: 10.1, SKFChest: 6.2, SKFMidaxillary: 11.1, SKFSuprailiac: 42, SKFAbdominal: 26.3, SKFThigh: 26.4, SKFCalf: 16.7}, "for-age-16", 20.698, ""),
-		newCaseBodyFat(female, "2000-Mar-15", map[int]float64{SKFSubscapular: 29.7, SKFTriceps: 9.3, SKFChest: 9.7, SKFMidaxillary: 12.6, SKFSuprailiac: 36.7, SKFAbdominal: 17.5, SKFThigh: 28.4, SKFCalf: 9.6}, "for-age-12", 14.892, ""),
-		newCaseBodyFat(female, "2003-Mar-15", map[int]float64{SKFSubscapular: 29.7, SKFTriceps: 12.8, SKFChest: 8.2, SKFMidaxillary: 16.4, SKFSuprailiac: 24.5, SKFAbdominal: 26.7, SKFThigh: 30.1, SKFCalf: 25.6}, "for-age-15", 29.224, ""),
-		newCaseBodyFat(female, "1999-Mar-15", map[int]float64{SKFSubscapular: 16.1, SKFTriceps: 16.1, SKFChest: 11.6, SKFMidaxillary: 11.8, SKFSuprailiac: 42.6, SKFAbdominal: 32.2, SKFThigh: 14.1, SKFCalf: 14.9}, "for-age-11", 23.785, ""),
-		newCaseBodyFat(female, "2002-Mar-15", map[int]float64{SKFSubscapular: 24.1, SKFTriceps: 11.4, SKFChest: 6.3, SKFMidaxillary: 6.7, SKFSuprailiac: 38.9, SKFAbdominal: 18, SKFThigh: 34.6, SKFCalf: 18.4}, "for-age-14", 22.903, ""),
-		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFSubscapular: 34.5, SKFTriceps: 16.1, SKFChest: 8.7, SKFMidaxillary: 11.6, SKFSuprailiac: 45.3, SKFAbdominal: 15.3, SKFThigh: 27.5, SKFCalf: 19.1}, "for-age-7", 26.872, ""),
-		newCaseBodyFat(female, "2001-Mar-15", map[int]float64{SKFSubscapular: 28.1, SKFTriceps: 14.9, SKFChest: 12.6, SKFMidaxillary: 11.1, SKFSuprailiac: 18.5, SKFAbdominal: 44.1, SKFThigh: 26.4, SKFCalf: 21.4}, "for-age-13", 27.681, ""),
-		newCaseBodyFat(female, "2002-Mar-15", map[int]float64{SKFSubscapular: 23.1, SKFTriceps: 8.8, SKFChest: 11, SKFMidaxillary: 13.6, SKFSuprailiac: 20, SKFAbdominal: 32.9, SKFThigh: 20.9, SKFCalf: 8.2}, "for-age-14", 13.495, ""),
-		newCaseBodyFat(female, "2005-Mar-15", map[int]float64{SKFSubscapular: 18.9, SKFTriceps: 15.8, SKFChest: 12.7, SKFMidaxillary: 11.4, SKFSuprailiac: 25.3, SKFAbdominal: 29, SKFThigh: 20.2, SKFCalf: 16.8}, "for-age-17", 24.961, ""),
-		newCaseBodyFat(female, "1999-Mar-15", map[int]float64{SKFSubscapular: 20.9, SKFTriceps: 8, SKFChest: 6.5, SKFMidaxillary: 8.4, SKFSuprailiac: 45.4, SKFAbdominal: 15.6, SKFThigh: 33.5, SKFCalf: 23.1}, "for-age-11", 23.859, ""),
-		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFSubscapular: 30.8, SKFTriceps: 14.6, SKFChest: 7.2, SKFMidaxillary: 15, SKFSuprailiac: 22.8, SKFAbdominal: 43.2, SKFThigh: 22.5, SKFCalf: 8.4}, "for-age-7", 17.905, ""),
-		newCaseBodyFat(female, "1999-Mar-15", map[int]float64{SKFSubscapular: 27.4, SKFTriceps: 8.1, SKFChest: 7.8, SKFMidaxillary: 9.1, SKFSuprailiac: 44.1, SKFAbdominal: 24.7, SKFThigh: 34.1, SKFCalf: 23.9}, "for-age-11", 24.52, ""),
-		newCaseBodyFat(female, "1998-Mar-15", map[int]float64{SKFSubscapular: 27.5, SKFTriceps: 16.5, SKFChest: 7.8, SKFMidaxillary: 14.4, SKFSuprailiac: 33.9, SKFAbdominal: 38.2, SKFThigh: 16.5, SKFCalf: 19.9}, "for-age-10", 27.754, ""),
+		newCaseBodyFat(female, "2004-Mar-15", map[int]float64{SKFTriceps: 13.1, SKFChest: 7.5, SKFMidaxillary: 7.5, SKFSuprailiac: 30, SKFAbdominal: 18.5, SKFThigh: 31.1, SKFCalf: 14.5}, "for-age-16", 21.936, ""),
+		newCaseBodyFat(female, "2004-Mar-15", map[int]float64{SKFTriceps: 9.8, SKFChest: 9.6, SKFMidaxillary: 7.7, SKFSuprailiac: 21.1, SKFAbdominal: 34.1, SKFThigh: 20.8, SKFCalf: 20.6}, "for-age-16", 23.644, ""),
+		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFTriceps: 16.1, SKFChest: 11.9, SKFMidaxillary: 6.8, SKFSuprailiac: 36.5, SKFAbdominal: 45.4, SKFThigh: 34.6, SKFCalf: 10.4}, "for-age-7", 21.265, ""),
+		newCaseBodyFat(female, "2001-Mar-15", map[int]float64{SKFTriceps: 12.3, SKFChest: 12.8, SKFMidaxillary: 11.9, SKFSuprailiac: 36.9, SKFAbdominal: 32.7, SKFThigh: 27.9, SKFCalf: 17.4}, "for-age-13", 23.217, ""),
+		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFTriceps: 13.9, SKFChest: 8.2, SKFMidaxillary: 8.5, SKFSuprailiac: 30.8, SKFAbdominal: 25.1, SKFThigh: 13.7, SKFCalf: 20.1}, "for-age-7", 25.84, ""),
+		newCaseBodyFat(female, "2003-Mar-15", map[int]float64{SKFTriceps: 11.5, SKFChest: 8.2, SKFMidaxillary: 12.8, SKFSuprailiac: 31.9, SKFAbdominal: 20.1, SKFThigh: 31.2, SKFCalf: 16.8}, "for-age-15", 22.363, ""),
+		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFTriceps: 13.7, SKFChest: 6.5, SKFMidaxillary: 11.4, SKFSuprailiac: 38.6, SKFAbdominal: 29.3, SKFThigh: 27.6, SKFCalf: 20.7}, "for-age-7", 26.084, ""),
+		newCaseBodyFat(female, "2004-Mar-15", map[int]float64{SKFTriceps: 10.1, SKFChest: 6.2, SKFMidaxillary: 11.1, SKFSuprailiac: 42, SKFAbdominal: 26.3, SKFThigh: 26.4, SKFCalf: 16.7}, "for-age-16", 21.448, ""),
+		newCaseBodyFat(female, "2000-Mar-15", map[int]float64{SKFTriceps: 9.3, SKFChest: 9.7, SKFMidaxillary: 12.6, SKFSuprailiac: 36.7, SKFAbdominal: 17.5, SKFThigh: 28.4, SKFCalf: 9.6}, "for-age-12", 16.629, ""),
+		newCaseBodyFat(female, "2003-Mar-15", map[int]float64{SKFTriceps: 12.8, SKFChest: 8.2, SKFMidaxillary: 16.4, SKFSuprailiac: 24.5, SKFAbdominal: 26.7, SKFThigh: 30.1, SKFCalf: 25.6}, "for-age-15", 28.524, ""),
+		newCaseBodyFat(female, "1999-Mar-15", map[int]float64{SKFTriceps: 16.1, SKFChest: 11.6, SKFMidaxillary: 11.8, SKFSuprailiac: 42.6, SKFAbdominal: 32.2, SKFThigh: 14.1, SKFCalf: 14.9}, "for-age-11", 24.01, ""),
+		newCaseBodyFat(female, "2002-Mar-15", map[int]float64{SKFTriceps: 11.4, SKFChest: 6.3, SKFMidaxillary: 6.7, SKFSuprailiac: 38.9, SKFAbdominal: 18, SKFThigh: 34.6, SKFCalf: 18.4}, "for-age-14", 23.278, ""),
+		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFTriceps: 16.1, SKFChest: 8.7, SKFMidaxillary: 11.6, SKFSuprailiac: 45.3, SKFAbdominal: 15.3, SKFThigh: 27.5, SKFCalf: 19.1}, "for-age-7", 26.572, ""),
+		newCaseBodyFat(female, "2001-Mar-15", map[int]float64{SKFTriceps: 14.9, SKFChest: 12.6, SKFMidaxillary: 11.1, SKFSuprailiac: 18.5, SKFAbdominal: 44.1, SKFThigh: 26.4, SKFCalf: 21.4}, "for-age-13", 27.243, ""),
+		newCaseBodyFat(female, "2002-Mar-15", map[int]float64{SKFTriceps: 8.8, SKFChest: 11, SKFMidaxillary: 13.6, SKFSuprailiac: 20, SKFAbdominal: 32.9, SKFThigh: 20.9, SKFCalf: 8.2}, "for-age-14", 15.47, ""),
+		newCaseBodyFat(female, "2005-Mar-15", map[int]float64{SKFTriceps: 15.8, SKFChest: 12.7, SKFMidaxillary: 11.4, SKFSuprailiac: 25.3, SKFAbdominal: 29, SKFThigh: 20.2, SKFCalf: 16.8}, "for-age-17", 24.986, ""),
+		newCaseBodyFat(female, "1999-Mar-15", map[int]float64{SKFTriceps: 8, SKFChest: 6.5, SKFMidaxillary: 8.4, SKFSuprailiac: 45.4, SKFAbdominal: 15.6, SKFThigh: 33.5, SKFCalf: 23.1}, "for-age-11", 24.071, ""),
+		newCaseBodyFat(female, "1995-Mar-15", map[int]float64{SKFTriceps: 14.6, SKFChest: 7.2, SKFMidaxillary: 15, SKFSuprailiac: 22.8, SKFAbdominal: 43.2, SKFThigh: 22.5, SKFCalf: 8.4}, "for-age-7", 19.13, ""),
+		newCaseBodyFat(female, "1999-Mar-15", map[int]float64{SKFTriceps: 8.1, SKFChest: 7.8, SKFMidaxillary: 9.1, SKFSuprailiac: 44.1, SKFAbdominal: 24.7, SKFThigh: 34.1, SKFCalf: 23.9}, "for-age-11", 24.62, ""),
+		newCaseBodyFat(female, "1998-Mar-15", map[int]float64{SKFTriceps: 16.5, SKFChest: 7.8, SKFMidaxillary: 14.4, SKFSuprailiac: 33.9, SKFAbdominal: 38.2, SKFThigh: 16.5, SKFCalf: 19.9}, "for-age-10", 27.304, ""),
 	}
 
 	for _, data := range cases {
@@ -267,26 +267,26 @@ func TestMaleThreeSkinfoldEquation(t *testing.T) {
 
 func TestMaleTwoSkinfoldEquation(t *testing.T) {
 	cases := []caseBodyFat{
-		newCaseBodyFat(male, "1994-Dec-15", map[int]float64{SKFSubscapular: 17.6, SKFTriceps: 8.5, SKFChest: 9.8, SKFMidaxillary: 10.6, SKFSuprailiac: 40.3, SKFAbdominal: 28.4, SKFThigh: 34.6, SKFCalf: 18.4}, "for-age-16", 21.509, ""),
-		newCaseBodyFat(male, "1984-Dec-15", map[int]float64{SKFSubscapular: 26.6, SKFTriceps: 12, SKFChest: 9.7, SKFMidaxillary: 16.7, SKFSuprailiac: 43.9, SKFAbdominal: 42.8, SKFThigh: 10.3, SKFCalf: 24.1}, "for-age-6", 27.121, ""),
-		newCaseBodyFat(male, "1994-Dec-15", map[int]float64{SKFSubscapular: 19.7, SKFTriceps: 16.1, SKFChest: 8.8, SKFMidaxillary: 15.2, SKFSuprailiac: 17, SKFAbdominal: 35.3, SKFThigh: 28.7, SKFCalf: 24.4}, "for-age-16", 29.805, ""),
-		newCaseBodyFat(male, "1989-Dec-15", map[int]float64{SKFSubscapular: 20.5, SKFTriceps: 10.3, SKFChest: 11.2, SKFMidaxillary: 16.3, SKFSuprailiac: 25, SKFAbdominal: 39.4, SKFThigh: 11.9, SKFCalf: 23.7}, "for-age-11", 25.84, ""),
-		newCaseBodyFat(male, "1989-Dec-15", map[int]float64{SKFSubscapular: 21.2, SKFTriceps: 11.3, SKFChest: 10.8, SKFMidaxillary: 18.7, SKFSuprailiac: 27.4, SKFAbdominal: 18.1, SKFThigh: 25.8, SKFCalf: 22.8}, "for-age-11", 25.901, ""),
-		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFSubscapular: 31.7, SKFTriceps: 9.4, SKFChest: 12.3, SKFMidaxillary: 10.4, SKFSuprailiac: 19.9, SKFAbdominal: 38.2, SKFThigh: 26.9, SKFCalf: 19.9}, "for-age-7", 22.973, ""),
-		newCaseBodyFat(male, "1990-Dec-15", map[int]float64{SKFSubscapular: 18.9, SKFTriceps: 15.9, SKFChest: 8, SKFMidaxillary: 7.6, SKFSuprailiac: 33.2, SKFAbdominal: 26.4, SKFThigh: 23.5, SKFCalf: 16.7}, "for-age-12", 24.986, ""),
-		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFSubscapular: 20.3, SKFTriceps: 11.4, SKFChest: 6.5, SKFMidaxillary: 15.9, SKFSuprailiac: 33.4, SKFAbdominal: 15.7, SKFThigh: 18.3, SKFCalf: 23.1}, "for-age-7", 26.145, ""),
-		newCaseBodyFat(male, "1992-Dec-15", map[int]float64{SKFSubscapular: 31.5, SKFTriceps: 16.9, SKFChest: 7, SKFMidaxillary: 13.2, SKFSuprailiac: 31.4, SKFAbdominal: 41, SKFThigh: 18.3, SKFCalf: 17.2}, "for-age-14", 25.901, ""),
-		newCaseBodyFat(male, "1990-Dec-15", map[int]float64{SKFSubscapular: 29.4, SKFTriceps: 13.7, SKFChest: 12.8, SKFMidaxillary: 8.3, SKFSuprailiac: 45.9, SKFAbdominal: 23.5, SKFThigh: 21.4, SKFCalf: 14.9}, "for-age-12", 22.546, ""),
-		newCaseBodyFat(male, "1989-Dec-15", map[int]float64{SKFSubscapular: 25.5, SKFTriceps: 13.7, SKFChest: 6.9, SKFMidaxillary: 12.1, SKFSuprailiac: 31.3, SKFAbdominal: 40, SKFThigh: 29.6, SKFCalf: 23.3}, "for-age-11", 27.67, ""),
-		newCaseBodyFat(male, "1994-Dec-15", map[int]float64{SKFSubscapular: 19.4, SKFTriceps: 13.7, SKFChest: 11.4, SKFMidaxillary: 13.4, SKFSuprailiac: 42, SKFAbdominal: 34.5, SKFThigh: 30.3, SKFCalf: 25.5}, "for-age-16", 29.012, ""),
-		newCaseBodyFat(male, "1987-Dec-15", map[int]float64{SKFSubscapular: 29.7, SKFTriceps: 9.8, SKFChest: 7.4, SKFMidaxillary: 9.4, SKFSuprailiac: 36.3, SKFAbdominal: 38.6, SKFThigh: 16, SKFCalf: 24.1}, "for-age-9", 25.779, ""),
-		newCaseBodyFat(male, "1986-Dec-15", map[int]float64{SKFSubscapular: 32.5, SKFTriceps: 9.7, SKFChest: 12.2, SKFMidaxillary: 14.4, SKFSuprailiac: 37, SKFAbdominal: 44.3, SKFThigh: 19.5, SKFCalf: 11.5}, "for-age-8", 18.032, ""),
-		newCaseBodyFat(male, "1992-Dec-15", map[int]float64{SKFSubscapular: 19.8, SKFTriceps: 16, SKFChest: 12, SKFMidaxillary: 16.1, SKFSuprailiac: 17, SKFAbdominal: 25.2, SKFThigh: 23.2, SKFCalf: 6.4}, "for-age-14", 18.764, ""),
-		newCaseBodyFat(male, "1989-Dec-15", map[int]float64{SKFSubscapular: 27.2, SKFTriceps: 11.7, SKFChest: 7.5, SKFMidaxillary: 15.9, SKFSuprailiac: 29, SKFAbdominal: 17.5, SKFThigh: 10, SKFCalf: 24.4}, "for-age-11", 27.121, ""),
-		newCaseBodyFat(male, "1993-Dec-15", map[int]float64{SKFSubscapular: 24.4, SKFTriceps: 10.3, SKFChest: 12.5, SKFMidaxillary: 9.3, SKFSuprailiac: 19.6, SKFAbdominal: 29.5, SKFThigh: 20.1, SKFCalf: 23.4}, "for-age-15", 25.657, ""),
-		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFSubscapular: 29.1, SKFTriceps: 11.2, SKFChest: 12.4, SKFMidaxillary: 12, SKFSuprailiac: 34.8, SKFAbdominal: 18.1, SKFThigh: 11.1, SKFCalf: 14.8}, "for-age-7", 20.96, ""),
-		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFSubscapular: 16.8, SKFTriceps: 16.6, SKFChest: 10.8, SKFMidaxillary: 7.6, SKFSuprailiac: 21.3, SKFAbdominal: 45.4, SKFThigh: 31.3, SKFCalf: 14.5}, "for-age-7", 24.071, ""),
-		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFSubscapular: 22.1, SKFTriceps: 16.4, SKFChest: 10.2, SKFMidaxillary: 9.8, SKFSuprailiac: 35.9, SKFAbdominal: 15.9, SKFThigh: 17.8, SKFCalf: 17.7}, "for-age-7", 25.901, ""),
+		newCaseBodyFat(male, "1994-Dec-15", map[int]float64{SKFTriceps: 8.5, SKFChest: 9.8, SKFMidaxillary: 10.6, SKFSuprailiac: 40.3, SKFAbdominal: 28.4, SKFThigh: 34.6, SKFCalf: 18.4}, "for-age-16", 20.771, ""),
+		newCaseBodyFat(male, "1984-Dec-15", map[int]float64{SKFTriceps: 12, SKFChest: 9.7, SKFMidaxillary: 16.7, SKFSuprailiac: 43.9, SKFAbdominal: 42.8, SKFThigh: 10.3, SKFCalf: 24.1}, "for-age-6", 27.534, ""),
+		newCaseBodyFat(male, "1994-Dec-15", map[int]float64{SKFTriceps: 16.1, SKFChest: 8.8, SKFMidaxillary: 15.2, SKFSuprailiac: 17, SKFAbdominal: 35.3, SKFThigh: 28.7, SKFCalf: 24.4}, "for-age-16", 30.767, ""),
+		newCaseBodyFat(male, "1989-Dec-15", map[int]float64{SKFTriceps: 10.3, SKFChest: 11.2, SKFMidaxillary: 16.3, SKFSuprailiac: 25, SKFAbdominal: 39.4, SKFThigh: 11.9, SKFCalf: 23.7}, "for-age-11", 25.99, ""),
+		newCaseBodyFat(male, "1989-Dec-15", map[int]float64{SKFTriceps: 11.3, SKFChest: 10.8, SKFMidaxillary: 18.7, SKFSuprailiac: 27.4, SKFAbdominal: 18.1, SKFThigh: 25.8, SKFCalf: 22.8}, "for-age-11", 26.064, ""),
+		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFTriceps: 9.4, SKFChest: 12.3, SKFMidaxillary: 10.4, SKFSuprailiac: 19.9, SKFAbdominal: 38.2, SKFThigh: 26.9, SKFCalf: 19.9}, "for-age-7", 22.535, ""),
+		newCaseBodyFat(male, "1990-Dec-15", map[int]float64{SKFTriceps: 15.9, SKFChest: 8, SKFMidaxillary: 7.6, SKFSuprailiac: 33.2, SKFAbdominal: 26.4, SKFThigh: 23.5, SKFCalf: 16.7}, "for-age-12", 24.961, ""),
+		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFTriceps: 11.4, SKFChest: 6.5, SKFMidaxillary: 15.9, SKFSuprailiac: 33.4, SKFAbdominal: 15.7, SKFThigh: 18.3, SKFCalf: 23.1}, "for-age-7", 26.357, ""),
+		newCaseBodyFat(male, "1992-Dec-15", map[int]float64{SKFTriceps: 16.9, SKFChest: 7, SKFMidaxillary: 13.2, SKFSuprailiac: 31.4, SKFAbdominal: 41, SKFThigh: 18.3, SKFCalf: 17.2}, "for-age-14", 26.063, ""),
+		newCaseBodyFat(male, "1990-Dec-15", map[int]float64{SKFTriceps: 13.7, SKFChest: 12.8, SKFMidaxillary: 8.3, SKFSuprailiac: 45.9, SKFAbdominal: 23.5, SKFThigh: 21.4, SKFCalf: 14.9}, "for-age-12", 22.021, ""),
+		newCaseBodyFat(male, "1989-Dec-15", map[int]float64{SKFTriceps: 13.7, SKFChest: 6.9, SKFMidaxillary: 12.1, SKFSuprailiac: 31.3, SKFAbdominal: 40, SKFThigh: 29.6, SKFCalf: 23.3}, "for-age-11", 28.195, ""),
+		newCaseBodyFat(male, "1994-Dec-15", map[int]float64{SKFTriceps: 13.7, SKFChest: 11.4, SKFMidaxillary: 13.4, SKFSuprailiac: 42, SKFAbdominal: 34.5, SKFThigh: 30.3, SKFCalf: 25.5}, "for-age-16", 29.812, ""),
+		newCaseBodyFat(male, "1987-Dec-15", map[int]float64{SKFTriceps: 9.8, SKFChest: 7.4, SKFMidaxillary: 9.4, SKFSuprailiac: 36.3, SKFAbdominal: 38.6, SKFThigh: 16, SKFCalf: 24.1}, "for-age-9", 25.917, ""),
+		newCaseBodyFat(male, "1986-Dec-15", map[int]float64{SKFTriceps: 9.7, SKFChest: 12.2, SKFMidaxillary: 14.4, SKFSuprailiac: 37, SKFAbdominal: 44.3, SKFThigh: 19.5, SKFCalf: 11.5}, "for-age-8", 16.582, ""),
+		newCaseBodyFat(male, "1992-Dec-15", map[int]float64{SKFTriceps: 16, SKFChest: 12, SKFMidaxillary: 16.1, SKFSuprailiac: 17, SKFAbdominal: 25.2, SKFThigh: 23.2, SKFCalf: 6.4}, "for-age-14", 17.464, ""),
+		newCaseBodyFat(male, "1989-Dec-15", map[int]float64{SKFTriceps: 11.7, SKFChest: 7.5, SKFMidaxillary: 15.9, SKFSuprailiac: 29, SKFAbdominal: 17.5, SKFThigh: 10, SKFCalf: 24.4}, "for-age-11", 27.533, ""),
+		newCaseBodyFat(male, "1993-Dec-15", map[int]float64{SKFTriceps: 10.3, SKFChest: 12.5, SKFMidaxillary: 9.3, SKFSuprailiac: 19.6, SKFAbdominal: 29.5, SKFThigh: 20.1, SKFCalf: 23.4}, "for-age-15", 25.77, ""),
+		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFTriceps: 11.2, SKFChest: 12.4, SKFMidaxillary: 12, SKFSuprailiac: 34.8, SKFAbdominal: 18.1, SKFThigh: 11.1, SKFCalf: 14.8}, "for-age-7", 20.11, ""),
+		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFTriceps: 16.6, SKFChest: 10.8, SKFMidaxillary: 7.6, SKFSuprailiac: 21.3, SKFAbdominal: 45.4, SKFThigh: 31.3, SKFCalf: 14.5}, "for-age-7", 23.858, ""),
+		newCaseBodyFat(male, "1985-Dec-15", map[int]float64{SKFTriceps: 16.4, SKFChest: 10.2, SKFMidaxillary: 9.8, SKFSuprailiac: 35.9, SKFAbdominal: 15.9, SKFThigh: 17.8, SKFCalf: 17.7}, "for-age-7", 26.063, ""),
 	}
 
 	for _, data := range cases {
@@ -299,6 +299,116 @@ func TestMaleTwoSkinfoldEquation(t *testing.T) {
 	}
 }
 
+func TestSlaughterSkinfoldEquation(t *testing.T) {
+	whitePrepubescent, blackPostpubescent, asianPubescent, pubescent := *male, *male, *male, *male
+	whitePrepubescent.Ethnicity, whitePrepubescent.PubertalStage = EthnicityWhite, PubertalStagePrepubescent
+	blackPostpubescent.Ethnicity, blackPostpubescent.PubertalStage = EthnicityAfricanAmerican, PubertalStagePostpubescent
+	asianPubescent.Ethnicity, asianPubescent.PubertalStage = EthnicityAsian, PubertalStagePubescent
+	pubescent.PubertalStage = PubertalStagePubescent
+
+	low := map[int]float64{SKFTriceps: 10, SKFSubscapular: 8, SKFCalf: 12}
+	high := map[int]float64{SKFTriceps: 20, SKFSubscapular: 20}
+
+	cases := []caseBodyFatFactory{
+		{NewWomenSlaughterSKF, newCaseBodyFat(female, "2000-Jan-01", low, "women-triceps-subscapular-low", 17.228, "")},
+		{NewWomenSlaughterSKF, newCaseBodyFat(female, "2000-Jan-01", high, "women-triceps-subscapular-high", 31.54, "")},
+		{NewWomenSlaughterSKF, newCaseBodyFat(female, "2000-Jan-01", map[int]float64{SKFTriceps: 10, SKFCalf: 12}, "women-triceps-calf", 18.52, "")},
+		{NewMenSlaughterSKF, newCaseBodyFat(&whitePrepubescent, "1991-Jan-01", low, "men-white-prepubescent", 17.488, "")},
+		{NewMenSlaughterSKF, newCaseBodyFat(&blackPostpubescent, "1991-Jan-01", low, "men-black-postpubescent", 12.388, "")},
+		{NewMenSlaughterSKF, newCaseBodyFat(male, "1991-Jan-01", high, "men-triceps-subscapular-high", 32.92, "")},
+		{NewMenSlaughterSKF, newCaseBodyFat(male, "1991-Jan-01", map[int]float64{SKFTriceps: 10, SKFCalf: 8}, "men-triceps-calf", 14.23, "")},
+		{NewMenSlaughterSKF, newCaseBodyFat(male, "1991-Jan-01", low, "men-without-maturation", 0.0, "Missing pubertal stage")},
+		{NewMenSlaughterSKF, newCaseBodyFat(&pubescent, "1991-Jan-01", low, "men-without-ethnicity", 0.0, "Missing ethnicity")},
+		{NewMenSlaughterSKF, newCaseBodyFat(&asianPubescent, "1991-Jan-01", low, "men-asian", 0.0, "Valid for ethnicity white or african american")},
+		{NewWomenSlaughterSKF, newCaseBodyFat(female, "2000-Jan-01", map[int]float64{SKFTriceps: 10}, "women-only-triceps", 0.0, "Missing skinfold subscapular or calf")},
+		{NewWomenSlaughterSKF, newCaseBodyFat(female, "2010-Jan-01", low, "women-too-old", 0.0, "Valid for age")},
+	}
+
+	assertBodyFatCases(t, cases)
+}
+
+func TestSlaughterSkinfoldInParams(t *testing.T) {
+	assessment, _ := NewAssessment("2000-Jan-01")
+	skinfolds := NewSkinfolds(map[int]float64{SKFTriceps: 10, SKFSubscapular: 8, SKFCalf: 12, SKFThigh: 20})
+
+	bc := NewWomenSlaughterSKF(female, assessment, skinfolds)
+	in := bc.EquationConf.Extract(bc)
+	if v := in["sskf"]; !floatEqual(v, 18, 0.001) {
+		t.Errorf("Sum of skinfolds should use triceps and subscapular, got %.2f", v)
+	}
+	for _, k := range []int{SKFCalf, SKFThigh} {
+		if _, ok := in[NamedSkinfold(k)]; ok {
+			t.Errorf("Skinfold %s should not be extracted", NamedSkinfold(k))
+		}
+	}
+
+	delete(skinfolds.Measures, SKFSubscapular)
+	if v := bc.EquationConf.Extract(bc)["sskf"]; !floatEqual(v, 22, 0.001) {
+		t.Errorf("Sum of skinfolds should use triceps and calf, got %.2f", v)
+	}
+}
+
+/**
+ * Test regional and athlete equations
+ */
+
+func TestRegionalSkinfoldEquation(t *testing.T) {
+	women := map[int]float64{SKFSubscapular: 15, SKFTriceps: 16, SKFMidaxillary: 10, SKFSuprailiac: 18, SKFAbdominal: 20, SKFThigh: 25, SKFCalf: 14}
+	men := map[int]float64{SKFSubscapular: 12, SKFTriceps: 10, SKFSuprailiac: 14, SKFAbdominal: 20, SKFCalf: 8}
+
+	cases := []caseBodyFatFactory{
+		{NewWomenGuedesSKF, newCaseBodyFat(female, "2010-Jan-01", women, "women-guedes", 25.071, "")},
+		{NewWomenPetroskiSKF, newCaseBodyFat(female, "2010-Jan-01", women, "women-petroski", 21.588, "")},
+		{NewMenGuedesSKF, newCaseBodyFat(male, "2000-Jan-01", men, "men-guedes", 16.475, "")},
+		{NewMenPetroskiSKF, newCaseBodyFat(male, "2000-Jan-01", men, "men-petroski", 13.972, "")},
+		{NewMenLohmanSKF, newCaseBodyFat(male, "2000-Jan-01", men, "men-lohman", 14.592, "")},
+		{NewWomenGuedesSKF, newCaseBodyFat(female, "2020-Jan-01", women, "women-guedes-too-old", 0.0, "Valid for age")},
+		{NewMenGuedesSKF, newCaseBodyFat(female, "2010-Jan-01", women, "men-guedes-wrong-gender", 0.0, "Valid for gender")},
+		{NewWomenPetroskiSKF, newCaseBodyFat(female, "2010-Jan-01", men, "women-petroski-missing", 0.0, "Missing skinfold mid-axillary")},
+		{NewMenLohmanSKF, newCaseBodyFat(male, "2040-Jan-01", men, "men-lohman-too-old", 0.0, "Valid for age")},
+	}
+
+	assertBodyFatCases(t, cases)
+}
+
+func TestAthleteSkinfoldEquation(t *testing.T) {
+	athleteMale, athleteFemale := *male, *female
+	athleteMale.Population = PopulationAthlete
+	athleteFemale.Population = PopulationAthlete
+	blackMale, blackFemale := athleteMale, athleteFemale
+	blackMale.Ethnicity = EthnicityAfricanAmerican
+	blackFemale.Ethnicity = EthnicityAfricanAmerican
+
+	women := map[int]float64{SKFSubscapular: 15, SKFTriceps: 16, SKFSuprailiac: 18, SKFAbdominal: 20, SKFThigh: 25}
+	men := map[int]float64{SKFSubscapular: 12, SKFTriceps: 10, SKFBiceps: 5, SKFSuprailiac: 14, SKFMidaxillary: 9, SKFSupraspinale: 9, SKFAbdominal: 20, SKFThigh: 14, SKFCalf: 8}
+
+	cases := []caseBodyFatFactory{
+		{NewWomenFaulknerSKF, newCaseBodyFat(&athleteFemale, "2010-Jan-01", women, "women-faulkner", 16.34, "")},
+		{NewWomenEvansSKF, newCaseBodyFat(&athleteFemale, "2010-Jan-01", women, "women-evans", 24.052, "")},
+		{NewWomenEvansSKF, newCaseBodyFat(&blackFemale, "2010-Jan-01", women, "women-evans-african-american", 22.054, "")},
+		{NewWomenThorlandSKF, newCaseBodyFat(&athleteFemale, "2004-Jan-01", women, "women-thorland", 23.578, "")},
+		{NewMenWithersSKF, newCaseBodyFat(&athleteMale, "2000-Jan-01", men, "men-withers", 13.657, "")},
+		{NewMenFaulknerSKF, newCaseBodyFat(&athleteMale, "2000-Jan-01", men, "men-faulkner", 14.351, "")},
+		{NewMenEvansSKF, newCaseBodyFat(&athleteMale, "2000-Jan-01", men, "men-evans", 13.513, "")},
+		{NewMenEvansSKF, newCaseBodyFat(&blackMale, "2000-Jan-01", men, "men-evans-african-american", 11.515, "")},
+		{NewMenReillySKF, newCaseBodyFat(&athleteMale, "2000-Jan-01", men, "men-reilly", 12.85, "")},
+		{NewMenThorlandSKF, newCaseBodyFat(&athleteMale, "1994-Jan-01", men, "men-thorland", 12.263, "")},
+		{NewMenWithersSKF, newCaseBodyFat(male, "2000-Jan-01", men, "men-withers-not-athlete", 0.0, "Valid for population athlete")},
+		{NewWomenEvansSKF, newCaseBodyFat(female, "2010-Jan-01", women, "women-evans-not-athlete", 0.0, "Valid for population athlete")},
+		{NewMenFaulknerSKF, newCaseBodyFat(male, "2000-Jan-01", men, "men-faulkner-not-athlete", 0.0, "Valid for population athlete")},
+		{NewMenThorlandSKF, newCaseBodyFat(&athleteMale, "2000-Jan-01", men, "men-thorland-too-old", 0.0, "Valid for age")},
+	}
+
+	assertBodyFatCases(t, cases)
+
+	// equations for healthy population remain valid for athletes.
+	assessment, _ := NewAssessment("2000-Jan-01")
+	bc := NewMenThreeSKF(&athleteMale, assessment, NewSkinfolds(map[int]float64{SKFChest: 8, SKFAbdominal: 20, SKFThigh: 14}))
+	if _, err := bc.Calc(); err != nil {
+		t.Errorf("Athlete should be valid for healthy population equation, instead got %s", err)
+	}
+}
+
 /**
  * Common data for testing
  */
@@ -324,6 +434,29 @@ func newCaseBodyFat(p *Person, d string, s map[int]float64, name string, calc fl
 	}
 }
 
+// caseBodyFatFactory pairs a body fat case with the factory for its
+// equation.
+type caseBodyFatFactory struct {
+	factory func(*Person, *Assessment, *Skinfolds) *BodyCompositionSKF
+	data    caseBodyFat
+}
+
+// assertBodyFatCases checks each case value, or its expected error.
+func assertBodyFatCases(t *testing.T, cases []caseBodyFatFactory) {
+	t.Helper()
+	for _, c := range cases {
+		data := c.data
+		bc := c.factory(data.person, data.assessment, data.skinfold)
+		if calc, err := bc.Calc(); data.err != "" && (err == nil || !strings.Contains(err.Error(), data.err)) {
+			t.Errorf("Case _%s_ failed, should show error %s, instead got %v", data.name, data.err, err)
+		} else if data.err == "" && err != nil {
+			t.Errorf("Case _%s_ failed, should not show a validation error, instead got %s", data.name, err)
+		} else if data.err == "" && !floatEqual(calc, data.calc, 0.009) {
+			t.Errorf("Case _%s_ failed, should have value %.4f, instead got %.4f", data.name, data.calc, calc)
+		}
+	}
+}
+
 var (
 	male, _   = NewPerson("Joao Paulo Dubas", "1978-Dec-15", Male)
 	female, _ = NewPerson("Ana Paula Dubas", "1988-Mar-15", Female)
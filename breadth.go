@@ -0,0 +1,375 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Constants
+ */
+
+// Bone breadth constants.
+const (
+	// BDHumerus: biepicondylar humerus breadth
+	BDHumerus int = iota
+	// BDFemur: biepicondylar femur breadth
+	BDFemur
+	// BDBiacromial: biacromial breadth
+	BDBiacromial
+	// BDBiiliocristal: biiliocristal breadth
+	BDBiiliocristal
+	// BDWrist: bistyloid wrist breadth
+	BDWrist
+	// BDAnkle: bimalleolar ankle breadth
+	BDAnkle
+)
+
+// Frame size methods.
+const (
+	// FrameByWrist: height to wrist circumference ratio.
+	FrameByWrist int = iota
+	// FrameByElbow: elbow (humerus) breadth for height.
+	FrameByElbow
+)
+
+/**
+ * Breadths
+ */
+
+// Breadths represent a collection of bone breadth measures, in cm.
+type Breadths struct {
+	Measures map[int]float64
+}
+
+// NewBreadths returns a new Breadths instance.
+func NewBreadths(measures map[int]float64) *Breadths {
+	return &Breadths{Measures: measures}
+}
+
+// GetName retrieves breadths measurement name.
+func (b *Breadths) GetName() string {
+	return "Breadths"
+}
+
+// Result show the Breadths representation.
+func (b *Breadths) Result() ([]string, error) {
+	rs := []string{}
+	for k, v := range b.Measures {
+		rs = append(rs, fmt.Sprintf("Breadth %s: %.2f cm.", NamedBreadth(k), v))
+	}
+	return rs, nil
+}
+
+// NamedBreadth returns the name for a given bone breadth constant.
+func NamedBreadth(name int) string {
+	named := map[int]string{
+		BDHumerus:       "humerus breadth",
+		BDFemur:         "femur breadth",
+		BDBiacromial:    "biacromial breadth",
+		BDBiiliocristal: "biiliocristal breadth",
+		BDWrist:         "wrist breadth",
+		BDAnkle:         "ankle breadth",
+	}
+	return named[name]
+}
+
+/**
+ * Frame size
+ */
+
+// FrameSize represents the body frame size, classified by height to wrist
+// circumference ratio or by elbow breadth for height.
+type FrameSize struct {
+	*Person
+	*Anthropometry
+	*Circumferences
+	*Breadths
+	Method int
+}
+
+// NewFrameSize returns a new FrameSize instance, for the given method.
+func NewFrameSize(p *Person, a *Anthropometry, c *Circumferences, b *Breadths, method int) *FrameSize {
+	return &FrameSize{p, a, c, b, method}
+}
+
+func (f *FrameSize) String() string {
+	c, _ := f.Classify()
+	return fmt.Sprintf("Frame size: %s", c)
+}
+
+// GetName returns this measurement name.
+func (f *FrameSize) GetName() string {
+	return "Frame size"
+}
+
+// Result returns the frame size index and classification.
+func (f *FrameSize) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := f.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	c, err := f.Classify()
+	if err != nil {
+		return rs, err
+	}
+
+	rs = append(
+		rs,
+		fmt.Sprintf("%s: %.2f.", frameConfs[f.Method].Name, v),
+		fmt.Sprintf("Frame size: %s.", c),
+	)
+	return rs, nil
+}
+
+// Classify returns the frame size classification.
+func (f *FrameSize) Classify() (string, error) {
+	v, err := f.Calc()
+	if err != nil {
+		return "", err
+	}
+
+	var limits map[int][2]float64
+	switch f.Method {
+	case FrameByWrist:
+		limits, err = frameLimitsByWrist(f.Person.Gender)
+	default:
+		limits, err = frameLimitsByElbow(f.Person.Gender, f.Anthropometry.Height)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return Classifier(v, limits, FrameClassification), nil
+}
+
+// Calc returns the height to wrist circumference ratio, or the elbow breadth
+// in cm, according to the method.
+func (f *FrameSize) Calc() (float64, error) {
+	conf, ok := frameConfs[f.Method]
+	if !ok {
+		return 0.0, fmt.Errorf("Unknown frame size method %d", f.Method)
+	}
+	return NewEquation(conf.Extract(f), conf).Calc()
+}
+
+/**
+ * Bone mass
+ */
+
+// BoneMass represents the skeletal mass estimated from height, wrist and
+// femur breadths.
+type BoneMass struct {
+	*Anthropometry
+	*Breadths
+}
+
+// NewBoneMass returns a new BoneMass instance.
+func NewBoneMass(a *Anthropometry, b *Breadths) *BoneMass {
+	return &BoneMass{a, b}
+}
+
+func (b *BoneMass) String() string {
+	v, _ := b.Calc()
+	return fmt.Sprintf("Bone mass: %.2f kg", v)
+}
+
+// GetName returns this measurement name.
+func (b *BoneMass) GetName() string {
+	return "Bone mass"
+}
+
+// Result returns the bone mass, in kg and as percentage of body weight.
+func (b *BoneMass) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := b.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	if b.Anthropometry.Weight <= 0 {
+		return rs, fmt.Errorf("Weight must be greater than zero")
+	}
+
+	rs = append(
+		rs,
+		fmt.Sprintf("Bone mass: %.2f kg.", v),
+		fmt.Sprintf("Bone mass: %.2f%% of body weight.", v/b.Anthropometry.Weight*100),
+	)
+	return rs, nil
+}
+
+// Calc returns the bone mass, in kg.
+func (b *BoneMass) Calc() (float64, error) {
+	return b.equation().Calc()
+}
+
+// equation returns an equation, used to calculate bone mass.
+func (b *BoneMass) equation() Equationer {
+	return NewEquation(boneMassConf.Extract(b), boneMassConf)
+}
+
+/**
+ * Equations
+ */
+
+var (
+	frameConfs = map[int]*EquationConf{
+		FrameByWrist: NewEquationConf(
+			"Height to wrist ratio",
+			frameInParams,
+			[]Validator{
+				ValidateMeasures([]string{"height", NamedCircumference(CCFWrist)}),
+			},
+			func(e *Equation) float64 {
+				h, _ := e.In("height")
+				w, _ := e.In(NamedCircumference(CCFWrist))
+				return h / w
+			},
+		),
+		FrameByElbow: NewEquationConf(
+			"Elbow breadth",
+			frameInParams,
+			[]Validator{
+				ValidateMeasures([]string{"height", NamedBreadth(BDHumerus)}),
+			},
+			func(e *Equation) float64 {
+				v, _ := e.In(NamedBreadth(BDHumerus))
+				return v
+			},
+		),
+	}
+	// Von Dobeln equation, modified by Rocha, with height and breadths in
+	// meters.
+	boneMassConf = NewEquationConf(
+		"Rocha bone mass",
+		func(i interface{}) InParams {
+			b := i.(*BoneMass)
+			r := map[string]float64{
+				"height": b.Anthropometry.Height,
+			}
+			for _, k := range []int{BDWrist, BDFemur} {
+				if v, ok := b.Breadths.Measures[k]; ok {
+					r[NamedBreadth(k)] = v
+				}
+			}
+			return r
+		},
+		[]Validator{
+			ValidateMeasures([]string{"height", NamedBreadth(BDWrist), NamedBreadth(BDFemur)}),
+		},
+		func(e *Equation) float64 {
+			h, _ := e.In("height")
+			w, _ := e.In(NamedBreadth(BDWrist))
+			f, _ := e.In(NamedBreadth(BDFemur))
+			return 3.02 * math.Pow(math.Pow(h/100, 2)*(w/100)*(f/100)*400, 0.712)
+		},
+	)
+)
+
+// frameInParams returns height, wrist circumference and humerus breadth,
+// when available, from a FrameSize.
+func frameInParams(i interface{}) InParams {
+	f := i.(*FrameSize)
+	r := map[string]float64{
+		"height": f.Anthropometry.Height,
+	}
+	if f.Circumferences != nil {
+		if v, ok := f.Circumferences.Measures[CCFWrist]; ok {
+			r[NamedCircumference(CCFWrist)] = v
+		}
+	}
+	if f.Breadths != nil {
+		if v, ok := f.Breadths.Measures[BDHumerus]; ok {
+			r[NamedBreadth(BDHumerus)] = v
+		}
+	}
+	return r
+}
+
+/**
+ * Classification
+ */
+
+// Frame size classification constants.
+const (
+	FrameSmall = iota
+	FrameMedium
+	FrameLarge
+)
+
+// FrameClassification map frame size constants to their string
+// representation.
+var FrameClassification = map[int]string{
+	FrameSmall:  "Small",
+	FrameMedium: "Medium",
+	FrameLarge:  "Large",
+}
+
+// newFrameLimits returns frame size limits for an index that grows with the
+// frame, based in the medium frame range.
+func newFrameLimits(lower, upper float64) map[int][2]float64 {
+	return map[int][2]float64{
+		FrameSmall:  {math.Inf(-1), lower},
+		FrameMedium: {lower, math.Nextafter(upper, math.Inf(+1))},
+		FrameLarge:  {math.Nextafter(upper, math.Inf(+1)), math.Inf(+1)},
+	}
+}
+
+// frameLimitsByWrist returns frame size limits for height to wrist
+// circumference ratio, from Grant. The ratio decreases as frame increases.
+func frameLimitsByWrist(gender int) (map[int][2]float64, error) {
+	limits := map[int]map[int][2]float64{
+		Male: {
+			FrameLarge:  {math.Inf(-1), 9.6},
+			FrameMedium: {9.6, math.Nextafter(10.4, math.Inf(+1))},
+			FrameSmall:  {math.Nextafter(10.4, math.Inf(+1)), math.Inf(+1)},
+		},
+		Female: {
+			FrameLarge:  {math.Inf(-1), 10.1},
+			FrameMedium: {10.1, math.Nextafter(11.0, math.Inf(+1))},
+			FrameSmall:  {math.Nextafter(11.0, math.Inf(+1)), math.Inf(+1)},
+		},
+	}
+	l, ok := limits[gender]
+	if !ok {
+		return nil, fmt.Errorf("No classification for gender %d", gender)
+	}
+	return l, nil
+}
+
+// frameLimitsByElbow returns frame size limits for elbow breadth, based in
+// height, from Metropolitan Life Insurance Company tables.
+func frameLimitsByElbow(gender int, height float64) (map[int][2]float64, error) {
+	l, ok := elbowBreadthLimits[gender]
+	if !ok {
+		return nil, fmt.Errorf("No classification for gender %d", gender)
+	}
+	for k, v := range l {
+		if height >= k[0] && height < k[1] {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("No classification for height %.0f", height)
+}
+
+// elbowBreadthLimits represent the medium frame elbow breadth, in cm, by
+// height, in cm.
+var elbowBreadthLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{math.Inf(-1), 162.6}: newFrameLimits(2.5*inch, 2.875*inch),
+		{162.6, 172.7}:        newFrameLimits(2.625*inch, 2.875*inch),
+		{172.7, 182.9}:        newFrameLimits(2.75*inch, 3.0*inch),
+		{182.9, 193.0}:        newFrameLimits(2.75*inch, 3.125*inch),
+		{193.0, math.Inf(+1)}: newFrameLimits(2.875*inch, 3.25*inch),
+	},
+	Female: {
+		{math.Inf(-1), 162.6}: newFrameLimits(2.25*inch, 2.5*inch),
+		{162.6, 182.9}:        newFrameLimits(2.375*inch, 2.625*inch),
+		{182.9, math.Inf(+1)}: newFrameLimits(2.5*inch, 2.75*inch),
+	},
+}
@@ -0,0 +1,100 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBreadths(t *testing.T) {
+	b := NewBreadths(map[int]float64{BDBiacromial: 40.1, BDWrist: 5.6})
+	rs, _ := b.Result()
+	if len(rs) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(rs))
+	}
+	for _, r := range rs {
+		if !strings.Contains(r, "biacromial breadth: 40.10 cm") && !strings.Contains(r, "wrist breadth: 5.60 cm") {
+			t.Errorf("Unexpected result %s", r)
+		}
+	}
+}
+
+func TestFrameSize(t *testing.T) {
+	cases := []struct {
+		person *Person
+		height float64
+		wrist  float64
+		elbow  float64
+		method int
+		calc   float64
+		class  int
+	}{
+		{male, 175, 17.5, 0, FrameByWrist, 10, FrameMedium},
+		{male, 175, 16, 0, FrameByWrist, 10.9375, FrameSmall},
+		{male, 175, 19, 0, FrameByWrist, 9.2105, FrameLarge},
+		{female, 162, 14, 0, FrameByWrist, 11.5714, FrameSmall},
+		{female, 162, 15.5, 0, FrameByWrist, 10.4516, FrameMedium},
+		{male, 175, 0, 7.3, FrameByElbow, 7.3, FrameMedium},
+		{male, 175, 0, 6.8, FrameByElbow, 6.8, FrameSmall},
+		{female, 165, 0, 6.8, FrameByElbow, 6.8, FrameLarge},
+	}
+
+	for _, c := range cases {
+		f := NewFrameSize(
+			c.person,
+			NewAnthropometry(70, c.height),
+			NewCircumferences(map[int]float64{CCFWrist: c.wrist}),
+			NewBreadths(map[int]float64{BDHumerus: c.elbow}),
+			c.method,
+		)
+		if v, err := f.Calc(); err != nil || !floatEqual(v, c.calc, 0.0001) {
+			t.Errorf("Frame index expected %.4f, got %.4f (%v)", c.calc, v, err)
+		}
+		if class, _ := f.Classify(); class != FrameClassification[c.class] {
+			t.Errorf("Frame size expected %s, got %s", FrameClassification[c.class], class)
+		}
+		if _, err := f.Result(); err != nil {
+			t.Errorf("Unexpected result error: %s", err)
+		}
+	}
+
+	noWrist := NewFrameSize(male, NewAnthropometry(70, 175), nil, NewBreadths(map[int]float64{}), FrameByWrist)
+	if _, err := noWrist.Calc(); err == nil || !strings.Contains(err.Error(), "Missing wrist measure") {
+		t.Errorf("Expected missing wrist error, got %v", err)
+	}
+	unknown := NewFrameSize(male, NewAnthropometry(70, 175), nil, nil, 10)
+	if _, err := unknown.Classify(); err == nil {
+		t.Error("Expected error for unknown frame size method")
+	}
+}
+
+func TestBoneMass(t *testing.T) {
+	cases := []struct {
+		height float64
+		wrist  float64
+		femur  float64
+		calc   float64
+	}{
+		{175, 5.6, 9.7, 11.6426},
+		{162, 5.0, 8.8, 8.9776},
+	}
+
+	for _, c := range cases {
+		b := NewBoneMass(NewAnthropometry(70, c.height), NewBreadths(map[int]float64{BDWrist: c.wrist, BDFemur: c.femur}))
+		if v, err := b.Calc(); err != nil || !floatEqual(v, c.calc, 0.0001) {
+			t.Errorf("Bone mass expected %.4f, got %.4f (%v)", c.calc, v, err)
+		}
+	}
+
+	b := NewBoneMass(NewAnthropometry(70, 175), NewBreadths(map[int]float64{BDWrist: 5.6}))
+	if _, err := b.Calc(); err == nil || !strings.Contains(err.Error(), "Missing femur breadth measure") {
+		t.Errorf("Expected missing femur error, got %v", err)
+	}
+
+	b = NewBoneMass(NewAnthropometry(0, 175), NewBreadths(map[int]float64{BDWrist: 5.6, BDFemur: 9.7}))
+	if v, err := b.Calc(); err != nil || !floatEqual(v, 11.6426, 0.0001) {
+		t.Errorf("Bone mass should not depend on weight, got %.4f (%v)", v, err)
+	}
+	if _, err := b.Result(); err == nil || !strings.Contains(err.Error(), "Weight must be greater than zero") {
+		t.Errorf("Expected weight error, got %v", err)
+	}
+}
@@ -39,6 +39,10 @@ const (
 	CCFLeftThigh
 	// CCFLeftCalf: left calf circumference
 	CCFLeftCalf
+	// CCFWrist: wrist circumference
+	CCFWrist
+	// CCFRightFlexedArm: right arm circumference, flexed and tensed
+	CCFRightFlexedArm
 )
 
 /**
@@ -202,20 +206,22 @@ func (c *Circumferences) Result() ([]string, error) {
 // NamedCircumference returns the name for a given circumference constant.
 func NamedCircumference(name int) string {
 	named := map[int]string{
-		CCFNeck:         "neck",
-		CCFShoulder:     "shoulder",
-		CCFChest:        "chest",
-		CCFWaist:        "waist",
-		CCFAbdominal:    "abdominal",
-		CCFHip:          "hip",
-		CCFRightArm:     "right arm",
-		CCFRightForeArm: "right forearm",
-		CCFRightThigh:   "right thigh",
-		CCFRightCalf:    "right calf",
-		CCFLeftArm:      "left arm",
-		CCFLeftForeArm:  "left forearm",
-		CCFLeftThigh:    "left thigh",
-		CCFLeftCalf:     "left calf",
+		CCFNeck:           "neck",
+		CCFShoulder:       "shoulder",
+		CCFChest:          "chest",
+		CCFWaist:          "waist",
+		CCFAbdominal:      "abdominal",
+		CCFHip:            "hip",
+		CCFRightArm:       "right arm",
+		CCFRightForeArm:   "right forearm",
+		CCFRightThigh:     "right thigh",
+		CCFRightCalf:      "right calf",
+		CCFLeftArm:        "left arm",
+		CCFLeftForeArm:    "left forearm",
+		CCFLeftThigh:      "left thigh",
+		CCFLeftCalf:       "left calf",
+		CCFWrist:          "wrist",
+		CCFRightFlexedArm: "right flexed arm",
 	}
 	return named[name]
 }
@@ -279,19 +285,7 @@ var (
 // gender and age. In case neither gender nor age match any map, an error is
 // returned.
 func wthLimitsForGenderAndAge(gender int, age float64) (map[int][2]float64, error) {
-	genderClass, ok := wthLimits[gender]
-	if !ok {
-		return nil, fmt.Errorf("No classification for gender %d", gender)
-	}
-
-	for limits, classes := range genderClass {
-		if age < limits[0] || age >= limits[1] {
-			continue
-		}
-		return classes, nil
-	}
-
-	return nil, fmt.Errorf("No classification for age %.0f", age)
+	return limitsForGenderAndAge(wthLimits, gender, age)
 }
 
 // Waist-to-hip classification constants.
@@ -1,6 +1,10 @@
 package phass
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"strings"
+)
 
 /**
  * Equation
@@ -135,6 +139,75 @@ func validateAge(lower, upper float64, e *Equation) (bool, error) {
 	return true, nil
 }
 
+// ValidatePopulation returns a Validator function, that ensure population is
+// equal to the one expected.
+func ValidatePopulation(expect int) Validator {
+	return func(e *Equation) (bool, error) {
+		return validatePopulation(expect, e)
+	}
+}
+
+// validatePopulation ensure that population is set and matches the expected
+// value.
+func validatePopulation(expect int, e *Equation) (bool, error) {
+	if p, ok := e.In("population"); !ok {
+		return false, fmt.Errorf("Missing population")
+	} else if int(p) != expect {
+		return false, fmt.Errorf("Valid for population %s", NamedPopulation(expect))
+	}
+	return true, nil
+}
+
+// ValidateEthnicity returns a Validator function, that ensure ethnicity is
+// one of the expected.
+func ValidateEthnicity(expect ...int) Validator {
+	return func(e *Equation) (bool, error) {
+		return validateEthnicity(expect, e)
+	}
+}
+
+// validateEthnicity ensure that ethnicity is set and matches one of the
+// expected values.
+func validateEthnicity(expect []int, e *Equation) (bool, error) {
+	v, ok := e.In("ethnicity")
+	if !ok {
+		return false, fmt.Errorf("Missing ethnicity")
+	}
+	names := []string{}
+	for _, k := range expect {
+		if int(v) == k {
+			return true, nil
+		}
+		names = append(names, NamedEthnicity(k))
+	}
+	return false, fmt.Errorf("Valid for ethnicity %s", strings.Join(names, " or "))
+}
+
+// ValidatePubertalStage returns a Validator function, that ensure pubertal
+// stage is one of the expected.
+func ValidatePubertalStage(expect ...int) Validator {
+	return func(e *Equation) (bool, error) {
+		return validatePubertalStage(expect, e)
+	}
+}
+
+// validatePubertalStage ensure that pubertal stage is set and matches one of
+// the expected values.
+func validatePubertalStage(expect []int, e *Equation) (bool, error) {
+	v, ok := e.In("pubertal stage")
+	if !ok {
+		return false, fmt.Errorf("Missing pubertal stage")
+	}
+	names := []string{}
+	for _, k := range expect {
+		if int(v) == k {
+			return true, nil
+		}
+		names = append(names, NamedPubertalStage(k))
+	}
+	return false, fmt.Errorf("Valid for pubertal stage %s", strings.Join(names, " or "))
+}
+
 // ValidateMeasures returns a Validator function, that ensure a list of
 // expected measures are available.
 func ValidateMeasures(expect []string) Validator {
@@ -153,6 +226,24 @@ func validateMeasures(expect []string, e *Equation) (bool, error) {
 	return true, nil
 }
 
+// ValidateRange returns a Validator function, that ensure a given input
+// parameter is between lower and upper limits.
+func ValidateRange(name string, lower, upper float64) Validator {
+	return func(e *Equation) (bool, error) {
+		return validateRange(name, lower, upper, e)
+	}
+}
+
+// validateRange ensure that an input parameter is set and is between limits.
+func validateRange(name string, lower, upper float64, e *Equation) (bool, error) {
+	if v, ok := e.In(name); !ok {
+		return false, fmt.Errorf("Missing %s measure", name)
+	} else if v < lower || v > upper {
+		return false, fmt.Errorf("Valid for %s between %.0f and %.0f", name, lower, upper)
+	}
+	return true, nil
+}
+
 /**
  * Classification
  */
@@ -182,3 +273,75 @@ func classifierIndex(value float64, classes map[int][2]float64) int {
 	}
 	return cid
 }
+
+// limitsForGenderAndAge return the classification map for a given gender and
+// age, from a table mapping gender to age limits and classification maps. In
+// case neither gender nor age match any map, an error is returned.
+func limitsForGenderAndAge(table map[int]map[[2]float64]map[int][2]float64, gender int, age float64) (map[int][2]float64, error) {
+	genderClass, ok := table[gender]
+	if !ok {
+		return nil, fmt.Errorf("No classification for gender %d", gender)
+	}
+
+	for limits, classes := range genderClass {
+		if age < limits[0] || age >= limits[1] {
+			continue
+		}
+		return classes, nil
+	}
+
+	return nil, fmt.Errorf("No classification for age %.0f", age)
+}
+
+// Fitness classification constants, used by normative tables of field tests.
+const (
+	FitnessNeedsImprovement = iota
+	FitnessFair
+	FitnessGood
+	FitnessVeryGood
+	FitnessExcellent
+)
+
+// FitnessClassification map fitness classification constants to their string
+// representation.
+var FitnessClassification = map[int]string{
+	FitnessNeedsImprovement: "Needs improvement",
+	FitnessFair:             "Fair",
+	FitnessGood:             "Good",
+	FitnessVeryGood:         "Very good",
+	FitnessExcellent:        "Excellent",
+}
+
+// newFitnessLimits returns fitness classification limits, based in the lower
+// limits for fair, good, very good and excellent classes.
+func newFitnessLimits(fair, good, veryGood, excellent float64) map[int][2]float64 {
+	return map[int][2]float64{
+		FitnessNeedsImprovement: {math.Inf(-1), fair},
+		FitnessFair:             {fair, good},
+		FitnessGood:             {good, veryGood},
+		FitnessVeryGood:         {veryGood, excellent},
+		FitnessExcellent:        {excellent, math.Inf(+1)},
+	}
+}
+
+/**
+ * Interpolation
+ */
+
+// interpolate returns the value for x, linearly interpolated from a table of
+// (x, y) points sorted by x. Values outside the table are clamped to the
+// first or last point.
+func interpolate(x float64, table [][2]float64) float64 {
+	if x <= table[0][0] {
+		return table[0][1]
+	}
+	for i := 1; i < len(table); i++ {
+		if x > table[i][0] {
+			continue
+		}
+		x0, y0 := table[i-1][0], table[i-1][1]
+		x1, y1 := table[i][0], table[i][1]
+		return y0 + (y1-y0)*(x-x0)/(x1-x0)
+	}
+	return table[len(table)-1][1]
+}
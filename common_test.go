@@ -100,6 +100,86 @@ func TestAgeValidator(t *testing.T) {
 	}
 }
 
+func TestRangeValidator(t *testing.T) {
+	cases := []caseCommon{
+		{in: map[string]float64{}, ok: false, err: "Missing heart rate"},
+		{in: map[string]float64{"heart rate": 119}, ok: false, err: "Valid for heart rate"},
+		{in: map[string]float64{"heart rate": 171}, ok: false, err: "Valid for heart rate"},
+	}
+
+	validator := ValidateRange("heart rate", 120, 170)
+	for _, data := range cases {
+		eq := NewEquation(data.in, conf).(*Equation)
+		if ok, err := validator(eq); ok != data.ok {
+			t.Error("Should receive a proper boolean")
+		} else if !strings.Contains(err.Error(), data.err) {
+			t.Error("Should show proper error message")
+		}
+	}
+
+	eq := NewEquation(map[string]float64{"heart rate": 150}, conf).(*Equation)
+	if ok, err := validator(eq); !ok || err != nil {
+		t.Error("Should be valid")
+	}
+}
+
+func TestPopulationValidator(t *testing.T) {
+	cases := []caseCommon{
+		{in: map[string]float64{}, ok: false, err: "Missing population"},
+		{in: map[string]float64{"population": float64(PopulationHealthy)}, ok: false, err: "Valid for population athlete"},
+	}
+
+	validator := ValidatePopulation(PopulationAthlete)
+	for _, data := range cases {
+		eq := NewEquation(data.in, conf).(*Equation)
+		if ok, err := validator(eq); ok != data.ok {
+			t.Error("Should receive a proper boolean")
+		} else if !strings.Contains(err.Error(), data.err) {
+			t.Error("Should show proper error message")
+		}
+	}
+
+	eq := NewEquation(map[string]float64{"population": float64(PopulationAthlete)}, conf).(*Equation)
+	if ok, err := validator(eq); !ok || err != nil {
+		t.Error("Should be valid")
+	}
+}
+
+func TestEthnicityAndPubertalStageValidator(t *testing.T) {
+	cases := []struct {
+		validator Validator
+		in        InParams
+		err       string
+	}{
+		{ValidateEthnicity(EthnicityWhite), map[string]float64{}, "Missing ethnicity"},
+		{ValidateEthnicity(EthnicityWhite), map[string]float64{"ethnicity": float64(EthnicityAsian)}, "Valid for ethnicity white"},
+		{ValidatePubertalStage(PubertalStagePubescent), map[string]float64{}, "Missing pubertal stage"},
+		{ValidatePubertalStage(PubertalStagePubescent), map[string]float64{"pubertal stage": float64(PubertalStagePrepubescent)}, "Valid for pubertal stage pubescent"},
+		{ValidateEthnicity(EthnicityWhite, EthnicityAsian), map[string]float64{"ethnicity": float64(EthnicityHispanic)}, "Valid for ethnicity white or asian"},
+		{ValidatePubertalStage(PubertalStagePrepubescent, PubertalStagePubescent), map[string]float64{"pubertal stage": float64(PubertalStagePostpubescent)}, "Valid for pubertal stage prepubescent or pubescent"},
+	}
+
+	for _, data := range cases {
+		eq := NewEquation(data.in, conf).(*Equation)
+		if ok, err := data.validator(eq); ok {
+			t.Error("Should receive a proper boolean")
+		} else if !strings.Contains(err.Error(), data.err) {
+			t.Error("Should show proper error message")
+		}
+	}
+
+	eq := NewEquation(map[string]float64{"ethnicity": float64(EthnicityWhite), "pubertal stage": float64(PubertalStagePubescent)}, conf).(*Equation)
+	if ok, err := ValidateEthnicity(EthnicityWhite)(eq); !ok || err != nil {
+		t.Error("Should be valid")
+	}
+	if ok, err := ValidatePubertalStage(PubertalStagePubescent)(eq); !ok || err != nil {
+		t.Error("Should be valid")
+	}
+	if ok, err := ValidateEthnicity(EthnicityAsian, EthnicityWhite)(eq); !ok || err != nil {
+		t.Error("Should be valid")
+	}
+}
+
 func TestMeasureValidator(t *testing.T) {
 	cases := []caseCommon{}
 	validator := ValidateMeasures([]string{"age", "weight", "height"})
@@ -0,0 +1,200 @@
+package phass
+
+import "fmt"
+
+/**
+ * Multi-compartment models
+ */
+
+// Multi-compartment models to estimate body fat percentage.
+var (
+	NewSiri3C   = FactoryMultiCompartment(confSiri3C)
+	NewLohman4C = FactoryMultiCompartment(confLohman4C)
+	NewWang4C   = FactoryMultiCompartment(confWang4C)
+)
+
+/**
+ * Multi-compartment
+ */
+
+// MultiCompartment contains data needed to estimate body composition by a
+// multi-compartment model. This is a composition of a person, assessment
+// details, anthropometric data, body density (in g/cm^3), total body water
+// (in L), bone mineral content (in kg) and the model equation.
+type MultiCompartment struct {
+	*Person
+	*Assessment
+	*Anthropometry
+	Density        float64
+	TotalBodyWater float64
+	BoneMineral    float64
+	*EquationConf
+}
+
+// FactoryMultiCompartment factory to create new body composition assessment
+// by multi-compartment models. It returns a function to create new
+// MultiCompartment structs.
+func FactoryMultiCompartment(conf MultiCompartmentConf) func(*Person, *Assessment, *Anthropometry, float64, float64, float64) *MultiCompartment {
+	c := NewEquationConfForMultiCompartment(conf)
+	return func(p *Person, a *Assessment, an *Anthropometry, density, tbw, bmc float64) *MultiCompartment {
+		return &MultiCompartment{p, a, an, density, tbw, bmc, c}
+	}
+}
+
+func (m *MultiCompartment) String() string {
+	v, _ := m.Calc()
+	return fmt.Sprintf("Body fat: %.2f %%", v)
+}
+
+// GetName returns this measurement name.
+func (m *MultiCompartment) GetName() string {
+	return "Multi-compartment body composition"
+}
+
+// Result returns information about body composition assessed by the model.
+func (m *MultiCompartment) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := m.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	fm, _ := m.FatMass()
+	ffm, _ := m.FatFreeMass()
+	rs = append(
+		rs,
+		fmt.Sprintf("%s body fat: %.2f %%", m.EquationConf.Name, v),
+		fmt.Sprintf("Fat mass: %.2f kg", fm),
+		fmt.Sprintf("Fat-free mass: %.2f kg", ffm),
+	)
+	return rs, nil
+}
+
+// FatMass returns the fat mass, in kg.
+func (m *MultiCompartment) FatMass() (float64, error) {
+	v, err := m.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	return m.Anthropometry.Weight * v / 100, nil
+}
+
+// FatFreeMass returns the fat-free mass, in kg.
+func (m *MultiCompartment) FatFreeMass() (float64, error) {
+	fm, err := m.FatMass()
+	if err != nil {
+		return 0.0, err
+	}
+	return m.Anthropometry.Weight - fm, nil
+}
+
+// Compare returns the difference between body fat percentage estimated by
+// skinfolds and by this model, used as reference.
+func (m *MultiCompartment) Compare(b *BodyCompositionSKF) (float64, error) {
+	ref, err := m.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	v, err := b.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	return v - ref, nil
+}
+
+// Calc returns the body fat percentage.
+func (m *MultiCompartment) Calc() (float64, error) {
+	return m.equation().Calc()
+}
+
+// equation returns an equation, used to estimate body fat percentage.
+func (m *MultiCompartment) equation() Equationer {
+	return NewEquation(m.EquationConf.Extract(m), m.EquationConf)
+}
+
+/**
+ * Multi-compartment conf definition
+ */
+
+// totalMineral converts bone mineral content, measured by DXA, into total
+// body mineral.
+const totalMineral = 1.235
+
+// Popular multi-compartment models, with body water and mineral expressed as
+// fraction of body weight.
+var (
+	confSiri3C = MultiCompartmentConf{
+		name:     "Siri 3C",
+		measures: []string{"density", "water"},
+		equation: func(e *Equation) float64 {
+			d, _ := e.In("density")
+			w, _ := e.In("water")
+			return (2.118/d - 0.78*w - 1.354) * 100
+		},
+	}
+	confLohman4C = MultiCompartmentConf{
+		name:     "Lohman 4C",
+		measures: []string{"density", "water", "mineral"},
+		equation: func(e *Equation) float64 {
+			d, _ := e.In("density")
+			w, _ := e.In("water")
+			b, _ := e.In("mineral")
+			return (2.747/d - 0.714*w + 1.146*b - 2.0503) * 100
+		},
+	}
+	confWang4C = MultiCompartmentConf{
+		name:     "Wang 4C",
+		measures: []string{"density", "water", "mineral"},
+		equation: func(e *Equation) float64 {
+			d, _ := e.In("density")
+			w, _ := e.In("water")
+			b, _ := e.In("mineral")
+			return (2.748/d - 0.699*w + 1.129*b*totalMineral - 2.051) * 100
+		},
+	}
+)
+
+/**
+ * Multi-compartment conf
+ */
+
+// NewEquationConfForMultiCompartment returns an equation configuration based
+// in provided configuration.
+func NewEquationConfForMultiCompartment(conf MultiCompartmentConf) *EquationConf {
+	extractor := func(i interface{}) InParams {
+		m := i.(*MultiCompartment)
+		r := map[string]float64{
+			"weight": m.Anthropometry.Weight,
+		}
+		if m.Density > 0 {
+			r["density"] = m.Density
+		}
+		if m.Anthropometry.Weight > 0 {
+			if m.TotalBodyWater > 0 {
+				r["water"] = m.TotalBodyWater / m.Anthropometry.Weight
+			}
+			if m.BoneMineral > 0 {
+				r["mineral"] = m.BoneMineral / m.Anthropometry.Weight
+			}
+		}
+		return r
+	}
+	validators := []Validator{
+		func(e *Equation) (bool, error) {
+			if w, _ := e.In("weight"); w <= 0 {
+				return false, fmt.Errorf("Weight must be greater than zero")
+			}
+			return true, nil
+		},
+		ValidateMeasures(conf.measures),
+	}
+	return NewEquationConf(conf.name, extractor, validators, conf.equation)
+}
+
+// MultiCompartmentConf common configuration for multi-compartment models.
+type MultiCompartmentConf struct {
+	name     string
+	measures []string
+	equation Calculator
+}
@@ -0,0 +1,73 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMultiCompartment(t *testing.T) {
+	assessment, _ := NewAssessment("2016-Jan-01")
+	an := NewAnthropometry(70, 175)
+
+	cases := []struct {
+		factory func(*Person, *Assessment, *Anthropometry, float64, float64, float64) *MultiCompartment
+		fat     float64
+	}{
+		{NewSiri3C, 17.6113},
+		{NewLohman4C, 15.8649},
+		{NewWang4C, 17.7825},
+	}
+
+	for _, c := range cases {
+		m := c.factory(male, assessment, an, 1.06, 42, 2.8)
+		v, err := m.Calc()
+		if err != nil || !floatEqual(v, c.fat, 0.0001) {
+			t.Errorf("%s body fat expected %.4f, got %.4f (%v)", m.EquationConf.Name, c.fat, v, err)
+		}
+		if fm, _ := m.FatMass(); !floatEqual(fm, 70*c.fat/100, 0.0001) {
+			t.Errorf("%s fat mass expected %.4f, got %.4f", m.EquationConf.Name, 70*c.fat/100, fm)
+		}
+		if ffm, _ := m.FatFreeMass(); !floatEqual(ffm, 70-70*c.fat/100, 0.0001) {
+			t.Errorf("%s fat-free mass expected %.4f, got %.4f", m.EquationConf.Name, 70-70*c.fat/100, ffm)
+		}
+		if _, err := m.Result(); err != nil {
+			t.Errorf("Unexpected result error: %s", err)
+		}
+	}
+}
+
+func TestMultiCompartmentCompare(t *testing.T) {
+	assessment, _ := NewAssessment("2016-Jan-01")
+	skf := NewMenThreeSKF(male, assessment, NewSkinfolds(map[int]float64{SKFChest: 12, SKFAbdominal: 20, SKFThigh: 15}))
+	m := NewSiri3C(male, assessment, NewAnthropometry(70, 175), 1.06, 42, 0)
+
+	expect, _ := skf.Calc()
+	if v, err := m.Compare(skf); err != nil || !floatEqual(v, expect-17.6113, 0.0001) {
+		t.Errorf("Difference expected %.4f, got %.4f (%v)", expect-17.6113, v, err)
+	}
+
+	invalid := NewMenThreeSKF(female, assessment, NewSkinfolds(map[int]float64{}))
+	if _, err := m.Compare(invalid); err == nil {
+		t.Error("Expected error for invalid skinfold equation")
+	}
+}
+
+func TestMultiCompartmentErrors(t *testing.T) {
+	assessment, _ := NewAssessment("2016-Jan-01")
+
+	cases := []struct {
+		m   *MultiCompartment
+		err string
+	}{
+		{NewSiri3C(male, assessment, NewAnthropometry(0, 175), 1.06, 42, 0), "Weight must be greater than zero"},
+		{NewSiri3C(male, assessment, NewAnthropometry(70, 175), 0, 42, 0), "Missing density measure"},
+		{NewSiri3C(male, assessment, NewAnthropometry(70, 175), 1.06, 0, 0), "Missing water measure"},
+		{NewLohman4C(male, assessment, NewAnthropometry(70, 175), 1.06, 42, 0), "Missing mineral measure"},
+	}
+
+	for _, c := range cases {
+		if _, err := c.m.Result(); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("Expected error containing %q, got %v", c.err, err)
+		}
+	}
+}
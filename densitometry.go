@@ -0,0 +1,248 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Constants
+ */
+
+// Residual lung volume prediction constants.
+const (
+	// RVGoldman: Goldman and Becklake equations, for men and women.
+	RVGoldman int = iota
+	// RVBoren: Boren, Kory and Syner equation, for men.
+	RVBoren
+)
+
+// giVolume represents the gastrointestinal gas volume, in L.
+const giVolume = 0.1
+
+/**
+ * Hydrostatic weighing
+ */
+
+// HydrostaticWeighing contains data needed to estimate body density by
+// underwater weighing. This is a composition of a person, assessment details,
+// anthropometric data (dry weight and height), water temperature (in C),
+// residual lung volume (in L), and underwater weight trials (in kg), where
+// the heaviest trial is used. When residual volume is not measured it should
+// be left as zero, and will be predicted with the chosen equation.
+type HydrostaticWeighing struct {
+	*Person
+	*Assessment
+	*Anthropometry
+	Temperature    float64
+	ResidualVolume float64
+	Prediction     int
+	Trials         []float64
+}
+
+// NewHydrostaticWeighing returns a new HydrostaticWeighing instance.
+func NewHydrostaticWeighing(p *Person, a *Assessment, an *Anthropometry, temperature, residualVolume float64, trials ...float64) *HydrostaticWeighing {
+	return &HydrostaticWeighing{
+		Person:         p,
+		Assessment:     a,
+		Anthropometry:  an,
+		Temperature:    temperature,
+		ResidualVolume: residualVolume,
+		Prediction:     RVGoldman,
+		Trials:         trials,
+	}
+}
+
+func (h *HydrostaticWeighing) String() string {
+	v, _ := h.Calc()
+	return fmt.Sprintf("Body density: %.4f g/cm^3", v)
+}
+
+// GetName returns this measurement name.
+func (h *HydrostaticWeighing) GetName() string {
+	return "Hydrostatic weighing"
+}
+
+// Result returns body density and body fat percentage from Siri and Brozek
+// conversions.
+func (h *HydrostaticWeighing) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := h.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	rv, _ := h.Volume()
+	siri, _ := h.Siri()
+	brozek, _ := h.Brozek()
+	rs = append(
+		rs,
+		fmt.Sprintf("Residual volume: %.2f L.", rv),
+		fmt.Sprintf("Body density: %.4f g/cm^3.", v),
+		fmt.Sprintf("Body fat (Siri): %.2f %%.", siri),
+		fmt.Sprintf("Body fat (Brozek): %.2f %%.", brozek),
+	)
+	return rs, nil
+}
+
+// Volume returns the residual lung volume, in L, measured or predicted.
+func (h *HydrostaticWeighing) Volume() (float64, error) {
+	if h.ResidualVolume > 0 {
+		return h.ResidualVolume, nil
+	}
+	conf, ok := residualVolumeConfs[h.Prediction]
+	if !ok {
+		return 0.0, fmt.Errorf("Unknown residual volume prediction %d", h.Prediction)
+	}
+	return NewEquation(conf.Extract(h), conf).Calc()
+}
+
+// Siri returns body fat percentage, from density, using Siri conversion.
+func (h *HydrostaticWeighing) Siri() (float64, error) {
+	d, err := h.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	return 495/d - 450, nil
+}
+
+// Brozek returns body fat percentage, from density, using Brozek conversion.
+func (h *HydrostaticWeighing) Brozek() (float64, error) {
+	d, err := h.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	return 457/d - 414.2, nil
+}
+
+// Calc returns the body density, in g/cm^3.
+func (h *HydrostaticWeighing) Calc() (float64, error) {
+	if _, err := h.Volume(); err != nil {
+		return 0.0, err
+	}
+	return h.equation().Calc()
+}
+
+// equation returns an equation, used to calculate body density.
+func (h *HydrostaticWeighing) equation() Equationer {
+	return NewEquation(densityConf.Extract(h), densityConf)
+}
+
+/**
+ * Equations
+ */
+
+var (
+	densityConf = NewEquationConf(
+		"Hydrostatic weighing",
+		func(i interface{}) InParams {
+			h := i.(*HydrostaticWeighing)
+			rv, _ := h.Volume()
+			r := map[string]float64{
+				"weight":            h.Anthropometry.Weight,
+				"water temperature": h.Temperature,
+				"residual volume":   rv,
+			}
+			if len(h.Trials) > 0 {
+				best := math.Inf(-1)
+				for _, v := range h.Trials {
+					best = math.Max(best, v)
+				}
+				r["underwater weight"] = best
+			}
+			return r
+		},
+		[]Validator{
+			ValidateMeasures([]string{"weight", "underwater weight", "water temperature", "residual volume"}),
+			ValidateRange("water temperature", 20, 37),
+			func(e *Equation) (bool, error) {
+				w, _ := e.In("weight")
+				uw, _ := e.In("underwater weight")
+				if w <= 0 || uw >= w {
+					return false, fmt.Errorf("Underwater weight must be lower than dry weight")
+				}
+				return true, nil
+			},
+		},
+		func(e *Equation) float64 {
+			w, _ := e.In("weight")
+			uw, _ := e.In("underwater weight")
+			t, _ := e.In("water temperature")
+			rv, _ := e.In("residual volume")
+			return w / ((w-uw)/waterDensity(t) - (rv + giVolume))
+		},
+	)
+	residualVolumeConfs = map[int]*EquationConf{
+		RVGoldman: NewEquationConf(
+			"Goldman and Becklake residual volume",
+			residualVolumeInParams,
+			[]Validator{
+				ValidateMeasures([]string{"gender", "age", "height"}),
+			},
+			func(e *Equation) float64 {
+				gender, _ := e.In("gender")
+				age, _ := e.In("age")
+				h, _ := e.In("height")
+				if int(gender) == Male {
+					return 0.017*age + 0.027*h - 3.477
+				}
+				return 0.009*age + 0.032*h - 3.900
+			},
+		),
+		RVBoren: NewEquationConf(
+			"Boren, Kory and Syner residual volume",
+			residualVolumeInParams,
+			[]Validator{
+				ValidateMeasures([]string{"gender", "age", "height", "weight"}),
+				ValidateGender(Male),
+			},
+			func(e *Equation) float64 {
+				age, _ := e.In("age")
+				h, _ := e.In("height")
+				w, _ := e.In("weight")
+				return 0.022*age + 0.0198*h - 0.015*w - 1.54
+			},
+		),
+	}
+)
+
+// residualVolumeInParams returns gender, age, height (in cm) and weight from
+// a HydrostaticWeighing.
+func residualVolumeInParams(i interface{}) InParams {
+	h := i.(*HydrostaticWeighing)
+	return map[string]float64{
+		"gender": float64(h.Person.Gender),
+		"age":    h.Person.AgeFromDate(h.Assessment.Date),
+		"height": h.Anthropometry.Height,
+		"weight": h.Anthropometry.Weight,
+	}
+}
+
+// waterDensity returns the water density, in g/cm^3, for a given temperature
+// in C.
+func waterDensity(temperature float64) float64 {
+	return interpolate(temperature, waterDensityTable)
+}
+
+// waterDensityTable represents water density, in g/cm^3, by temperature.
+var waterDensityTable = [][2]float64{
+	{20, 0.99821},
+	{21, 0.99799},
+	{22, 0.99777},
+	{23, 0.99754},
+	{24, 0.99730},
+	{25, 0.99705},
+	{26, 0.99679},
+	{27, 0.99652},
+	{28, 0.99624},
+	{29, 0.99595},
+	{30, 0.99565},
+	{31, 0.99534},
+	{32, 0.99503},
+	{33, 0.99470},
+	{34, 0.99437},
+	{35, 0.99403},
+	{36, 0.99369},
+	{37, 0.99333},
+}
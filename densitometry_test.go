@@ -0,0 +1,83 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHydrostaticWeighing(t *testing.T) {
+	assessment, _ := NewAssessment("2016-Jan-01")
+	an := NewAnthropometry(70, 175)
+
+	cases := []struct {
+		h       *HydrostaticWeighing
+		rv      float64
+		density float64
+		siri    float64
+		brozek  float64
+	}{
+		{NewHydrostaticWeighing(male, assessment, an, 34, 1.2, 2.8, 3.1, 3.0), 1.2, 1.0609, 16.5642, 16.5472},
+		{NewHydrostaticWeighing(male, assessment, an, 34, 0, 3.1), 1.877, 1.0719, 11.7769, 12.1273},
+		{NewHydrostaticWeighing(female, assessment, an, 34.5, 0, 2.5, 2.4), 1.943, 1.0630, 15.6591, 15.7115},
+	}
+
+	for _, c := range cases {
+		if v, err := c.h.Volume(); err != nil || !floatEqual(v, c.rv, 0.0001) {
+			t.Errorf("Residual volume expected %.4f, got %.4f (%v)", c.rv, v, err)
+		}
+		if v, err := c.h.Calc(); err != nil || !floatEqual(v, c.density, 0.0001) {
+			t.Errorf("Density expected %.4f, got %.4f (%v)", c.density, v, err)
+		}
+		if v, err := c.h.Siri(); err != nil || !floatEqual(v, c.siri, 0.0001) {
+			t.Errorf("Siri expected %.4f, got %.4f (%v)", c.siri, v, err)
+		}
+		if v, err := c.h.Brozek(); err != nil || !floatEqual(v, c.brozek, 0.0001) {
+			t.Errorf("Brozek expected %.4f, got %.4f (%v)", c.brozek, v, err)
+		}
+		if _, err := c.h.Result(); err != nil {
+			t.Errorf("Unexpected result error: %s", err)
+		}
+	}
+}
+
+func TestHydrostaticWeighingBoren(t *testing.T) {
+	assessment, _ := NewAssessment("2016-Jan-01")
+	an := NewAnthropometry(70, 175)
+
+	h := NewHydrostaticWeighing(male, assessment, an, 34, 0, 3.1)
+	h.Prediction = RVBoren
+	if v, err := h.Volume(); err != nil || !floatEqual(v, 1.689, 0.0001) {
+		t.Errorf("Residual volume expected %.4f, got %.4f (%v)", 1.689, v, err)
+	}
+
+	h = NewHydrostaticWeighing(female, assessment, an, 34, 0, 3.1)
+	h.Prediction = RVBoren
+	if _, err := h.Calc(); err == nil || !strings.Contains(err.Error(), "Valid for gender") {
+		t.Errorf("Expected gender error, got %v", err)
+	}
+}
+
+func TestHydrostaticWeighingErrors(t *testing.T) {
+	assessment, _ := NewAssessment("2016-Jan-01")
+	an := NewAnthropometry(70, 175)
+
+	unknown := NewHydrostaticWeighing(male, assessment, an, 34, 0, 3.1)
+	unknown.Prediction = -1
+
+	cases := []struct {
+		h   *HydrostaticWeighing
+		err string
+	}{
+		{NewHydrostaticWeighing(male, assessment, an, 34, 1.2), "Missing underwater weight measure"},
+		{NewHydrostaticWeighing(male, assessment, an, 15, 1.2, 3.1), "Valid for water temperature between 20 and 37"},
+		{NewHydrostaticWeighing(male, assessment, an, 34, 1.2, 71), "Underwater weight must be lower than dry weight"},
+		{NewHydrostaticWeighing(male, assessment, NewAnthropometry(0, 175), 34, 1.2, 3.1), "Underwater weight must be lower than dry weight"},
+		{unknown, "Unknown residual volume prediction -1"},
+	}
+
+	for _, c := range cases {
+		if _, err := c.h.Result(); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("Expected error containing %q, got %v", c.err, err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Muscular endurance tests
+ */
+
+// Muscular endurance field tests with their normative classification.
+var (
+	NewPushUp         = FactoryEndurance(confPushUp)
+	NewModifiedPushUp = FactoryEndurance(confModifiedPushUp)
+	NewPartialCurlUp  = FactoryEndurance(confPartialCurlUp)
+	NewSitUp          = FactoryEndurance(confSitUp)
+)
+
+/**
+ * Endurance
+ */
+
+// Endurance contains data needed to assess muscular endurance with a field
+// test. This is a composition of a person, assessment details, the number of
+// repetitions completed and the test configuration.
+type Endurance struct {
+	*Person
+	*Assessment
+	Repetitions float64
+	*EquationConf
+	limits map[int]map[[2]float64]map[int][2]float64
+	mapper map[int]string
+}
+
+// FactoryEndurance factory to create new muscular endurance tests. It returns
+// a function to create new Endurance structs.
+func FactoryEndurance(conf EnduranceConf) func(*Person, *Assessment, float64) *Endurance {
+	c := NewEquationConfForEndurance(conf)
+	return func(p *Person, a *Assessment, repetitions float64) *Endurance {
+		return &Endurance{p, a, repetitions, c, conf.limits, conf.mapper}
+	}
+}
+
+func (e *Endurance) String() string {
+	v, _ := e.Calc()
+	c, _ := e.Classify()
+	return fmt.Sprintf("%s: %.0f repetitions (%s)", e.EquationConf.Name, v, c)
+}
+
+// GetName returns this measurement name.
+func (e *Endurance) GetName() string {
+	return "Muscular endurance"
+}
+
+// Result returns relevant information about muscular endurance assessment.
+func (e *Endurance) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := e.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	c, err := e.Classify()
+	if err != nil {
+		return rs, err
+	}
+
+	rs = append(
+		rs,
+		fmt.Sprintf("%s: %.0f repetitions.", e.EquationConf.Name, v),
+		fmt.Sprintf("%s classification: %s.", e.EquationConf.Name, c),
+	)
+	return rs, nil
+}
+
+// Classify returns the normative classification for the repetitions.
+func (e *Endurance) Classify() (string, error) {
+	v, err := e.Calc()
+	if err != nil {
+		return "", err
+	}
+
+	classes, err := limitsForGenderAndAge(e.limits, e.Person.Gender, e.Person.AgeFromDate(e.Assessment.Date))
+	if err != nil {
+		return "", err
+	}
+
+	return Classifier(v, classes, e.mapper), nil
+}
+
+// Calc returns the number of repetitions.
+func (e *Endurance) Calc() (float64, error) {
+	return e.equation().Calc()
+}
+
+// equation returns an equation, used to validate the repetitions.
+func (e *Endurance) equation() Equationer {
+	return NewEquation(e.EquationConf.Extract(e), e.EquationConf)
+}
+
+/**
+ * Endurance conf definition
+ */
+
+// Popular muscular endurance tests and their normative tables.
+var (
+	confPushUp = EnduranceConf{
+		name:   "Push-up",
+		upper:  math.Inf(+1),
+		limits: pushUpLimits,
+		mapper: FitnessClassification,
+	}
+	confModifiedPushUp = EnduranceConf{
+		name:   "Modified push-up",
+		upper:  math.Inf(+1),
+		limits: modifiedPushUpLimits,
+		mapper: FitnessClassification,
+	}
+	confPartialCurlUp = EnduranceConf{
+		name:   "Partial curl-up",
+		upper:  25,
+		limits: partialCurlUpLimits,
+		mapper: FitnessClassification,
+	}
+	confSitUp = EnduranceConf{
+		name:   "1-minute sit-up",
+		upper:  math.Inf(+1),
+		limits: sitUpLimits,
+		mapper: RatingClassification,
+	}
+)
+
+/**
+ * Endurance conf
+ */
+
+// NewEquationConfForEndurance returns an equation configuration based in
+// provided configuration.
+func NewEquationConfForEndurance(conf EnduranceConf) *EquationConf {
+	extractor := func(i interface{}) InParams {
+		e := i.(*Endurance)
+		return map[string]float64{
+			"repetitions": e.Repetitions,
+		}
+	}
+	validators := []Validator{
+		ValidateMeasures([]string{"repetitions"}),
+		ValidateRange("repetitions", 0, conf.upper),
+	}
+	return NewEquationConf(conf.name, extractor, validators, func(e *Equation) float64 {
+		v, _ := e.In("repetitions")
+		return v
+	})
+}
+
+// EnduranceConf common configuration for muscular endurance tests.
+type EnduranceConf struct {
+	name   string
+	upper  float64
+	limits map[int]map[[2]float64]map[int][2]float64
+	mapper map[int]string
+}
+
+/**
+ * Classification
+ */
+
+// Rating classification constants.
+const (
+	RatingVeryPoor = iota
+	RatingPoor
+	RatingBelowAverage
+	RatingAverage
+	RatingAboveAverage
+	RatingGood
+	RatingExcellent
+)
+
+// RatingClassification map rating constants to their string representation.
+var RatingClassification = map[int]string{
+	RatingVeryPoor:     "Very poor",
+	RatingPoor:         "Poor",
+	RatingBelowAverage: "Below average",
+	RatingAverage:      "Average",
+	RatingAboveAverage: "Above average",
+	RatingGood:         "Good",
+	RatingExcellent:    "Excellent",
+}
+
+// newRatingLimits returns rating classification limits, based in the lower
+// limits for poor, below average, average, above average, good and excellent
+// classes.
+func newRatingLimits(poor, belowAverage, average, aboveAverage, good, excellent float64) map[int][2]float64 {
+	return map[int][2]float64{
+		RatingVeryPoor:     {math.Inf(-1), poor},
+		RatingPoor:         {poor, belowAverage},
+		RatingBelowAverage: {belowAverage, average},
+		RatingAverage:      {average, aboveAverage},
+		RatingAboveAverage: {aboveAverage, good},
+		RatingGood:         {good, excellent},
+		RatingExcellent:    {excellent, math.Inf(+1)},
+	}
+}
+
+// pushUpLimits represent the classification limits for push-ups, performed
+// by men, from the Canadian Physical Activity, Fitness and Lifestyle
+// Approach.
+var pushUpLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{15, 20}: newFitnessLimits(18, 23, 29, 39),
+		{20, 30}: newFitnessLimits(17, 22, 29, 36),
+		{30, 40}: newFitnessLimits(12, 17, 22, 30),
+		{40, 50}: newFitnessLimits(10, 13, 17, 25),
+		{50, 60}: newFitnessLimits(7, 10, 13, 21),
+		{60, 70}: newFitnessLimits(5, 8, 11, 18),
+	},
+}
+
+// modifiedPushUpLimits represent the classification limits for modified
+// push-ups, performed by women, from the Canadian Physical Activity, Fitness
+// and Lifestyle Approach.
+var modifiedPushUpLimits = map[int]map[[2]float64]map[int][2]float64{
+	Female: {
+		{15, 20}: newFitnessLimits(12, 18, 25, 33),
+		{20, 30}: newFitnessLimits(10, 15, 21, 30),
+		{30, 40}: newFitnessLimits(8, 13, 20, 27),
+		{40, 50}: newFitnessLimits(5, 11, 15, 24),
+		{50, 60}: newFitnessLimits(2, 7, 11, 21),
+		{60, 70}: newFitnessLimits(2, 5, 12, 17),
+	},
+}
+
+// partialCurlUpLimits represent the classification limits for partial
+// curl-ups, with a maximum of 25 repetitions, from the Canadian Physical
+// Activity, Fitness and Lifestyle Approach.
+var partialCurlUpLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{15, 20}: newFitnessLimits(11, 16, 21, 25),
+		{20, 30}: newFitnessLimits(11, 16, 21, 25),
+		{30, 40}: newFitnessLimits(11, 15, 18, 25),
+		{40, 50}: newFitnessLimits(6, 13, 18, 25),
+		{50, 60}: newFitnessLimits(8, 11, 17, 25),
+		{60, 70}: newFitnessLimits(6, 11, 16, 25),
+	},
+	Female: {
+		{15, 20}: newFitnessLimits(12, 17, 22, 25),
+		{20, 30}: newFitnessLimits(5, 14, 18, 25),
+		{30, 40}: newFitnessLimits(6, 10, 19, 25),
+		{40, 50}: newFitnessLimits(4, 11, 19, 25),
+		{50, 60}: newFitnessLimits(6, 10, 19, 25),
+		{60, 70}: newFitnessLimits(3, 8, 17, 25),
+	},
+}
+
+// sitUpLimits represent the classification limits for 1-minute sit-ups, from
+// the YMCA fitness testing and assessment manual.
+var sitUpLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{18, 26}:  newRatingLimits(25, 31, 35, 39, 44, 50),
+		{26, 36}:  newRatingLimits(22, 29, 31, 35, 40, 46),
+		{36, 46}:  newRatingLimits(17, 23, 27, 30, 35, 42),
+		{46, 56}:  newRatingLimits(13, 18, 22, 25, 29, 36),
+		{56, 66}:  newRatingLimits(9, 13, 17, 21, 25, 32),
+		{66, 120}: newRatingLimits(7, 11, 15, 19, 22, 29),
+	},
+	Female: {
+		{18, 26}:  newRatingLimits(18, 25, 29, 33, 37, 44),
+		{26, 36}:  newRatingLimits(13, 21, 25, 29, 33, 40),
+		{36, 46}:  newRatingLimits(7, 15, 19, 23, 27, 34),
+		{46, 56}:  newRatingLimits(5, 10, 14, 18, 22, 28),
+		{56, 66}:  newRatingLimits(3, 7, 10, 13, 18, 25),
+		{66, 120}: newRatingLimits(2, 5, 11, 14, 17, 24),
+	},
+}
@@ -0,0 +1,72 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnduranceCalcAndClassification(t *testing.T) {
+	type enduranceSpec struct {
+		factory     func(*Person, *Assessment, float64) *Endurance
+		person      *Person
+		assessment  string
+		repetitions float64
+		classify    string
+	}
+
+	specs := []enduranceSpec{
+		{NewPushUp, male, "2015-May-22", 25, FitnessClassification[FitnessVeryGood]},
+		{NewPushUp, male, "2015-May-22", 11, FitnessClassification[FitnessNeedsImprovement]},
+		{NewPushUp, male, "2003-May-22", 36, FitnessClassification[FitnessExcellent]},
+		{NewModifiedPushUp, female, "2015-May-22", 16, FitnessClassification[FitnessGood]},
+		{NewModifiedPushUp, female, "2045-May-22", 2, FitnessClassification[FitnessFair]},
+		{NewPartialCurlUp, male, "2015-May-22", 25, FitnessClassification[FitnessExcellent]},
+		{NewPartialCurlUp, female, "2015-May-22", 4, FitnessClassification[FitnessNeedsImprovement]},
+		{NewSitUp, male, "2003-May-22", 45, RatingClassification[RatingGood]},
+		{NewSitUp, male, "2015-May-22", 33, RatingClassification[RatingAboveAverage]},
+		{NewSitUp, female, "2015-May-22", 10, RatingClassification[RatingVeryPoor]},
+		{NewSitUp, female, "2058-May-22", 30, RatingClassification[RatingExcellent]},
+	}
+
+	for _, spec := range specs {
+		a, _ := NewAssessment(spec.assessment)
+		e := spec.factory(spec.person, a, spec.repetitions)
+
+		if calc, err := e.Calc(); err != nil {
+			t.Errorf("%s should not get an error: %s", e.EquationConf.Name, err)
+		} else if !floatEqual(calc, spec.repetitions, 0.001) {
+			t.Errorf("%s calc is %.0f, expected is %.0f", e.EquationConf.Name, calc, spec.repetitions)
+		}
+		if classify, _ := e.Classify(); classify != spec.classify {
+			t.Errorf("%s classify is %s, expected is %s", e.EquationConf.Name, classify, spec.classify)
+		}
+		if rs, err := e.Result(); err != nil || len(rs) != 2 {
+			t.Errorf("%s should have a result, instead got %v", e.EquationConf.Name, err)
+		}
+	}
+}
+
+func TestEnduranceInvalid(t *testing.T) {
+	a, _ := NewAssessment("2015-May-22")
+
+	type enduranceCase struct {
+		endurance *Endurance
+		err       string
+	}
+
+	cases := []enduranceCase{
+		{NewPushUp(male, a, -1), "Valid for repetitions"},
+		{NewPartialCurlUp(male, a, 26), "Valid for repetitions"},
+		{NewPushUp(female, a, 10), "No classification for gender"},
+		{NewModifiedPushUp(male, a, 10), "No classification for gender"},
+	}
+
+	for _, data := range cases {
+		if _, err := data.endurance.Classify(); err == nil || !strings.Contains(err.Error(), data.err) {
+			t.Errorf("Should show proper error message %s, got %v", data.err, err)
+		}
+		if _, err := data.endurance.Result(); err == nil {
+			t.Error("Result should show an error")
+		}
+	}
+}
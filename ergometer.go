@@ -0,0 +1,211 @@
+package phass
+
+import "fmt"
+
+/**
+ * Cycle ergometer protocols
+ */
+
+// Submaximal cycle ergometer protocols to estimate maximal oxygen uptake.
+var (
+	NewAstrandRyhming = FactoryCycleErgometer(astrandRyhmingConf)
+	NewYMCACycle      = FactoryCycleErgometer(ymcaCycleConf)
+)
+
+/**
+ * Stage
+ */
+
+// Stage represents a single stage in a graded exercise test, comprised of the
+// workload (in watts) and the steady-state heart rate (in bpm) achieved.
+type Stage struct {
+	Workload  float64
+	HeartRate float64
+}
+
+/**
+ * Cycle ergometer
+ */
+
+// CycleErgometer contains data needed to estimate the maximal oxygen uptake
+// from a submaximal cycle ergometer test. This is a composition of a person,
+// assessment details, anthropometry, the stages performed and an equation.
+type CycleErgometer struct {
+	*Person
+	*Assessment
+	*Anthropometry
+	Stages []Stage
+	*EquationConf
+}
+
+// FactoryCycleErgometer factory to create new cycle ergometer tests. It
+// returns a function to create new CycleErgometer structs.
+func FactoryCycleErgometer(conf *EquationConf) func(*Person, *Assessment, *Anthropometry, ...Stage) *CycleErgometer {
+	return func(p *Person, a *Assessment, an *Anthropometry, stages ...Stage) *CycleErgometer {
+		return NewCycleErgometer(p, a, an, stages, conf)
+	}
+}
+
+// NewCycleErgometer create a new cycle ergometer test. It receives a person,
+// an assessment, anthropometry, the stages performed, and the equation used
+// to estimate maximal oxygen uptake. Returns a pointer to CycleErgometer.
+func NewCycleErgometer(p *Person, a *Assessment, an *Anthropometry, stages []Stage, e *EquationConf) *CycleErgometer {
+	return &CycleErgometer{p, a, an, stages, e}
+}
+
+func (c *CycleErgometer) String() string {
+	v, _ := c.Calc()
+	return fmt.Sprintf("VO2max: %.2f ml/kg/min", v)
+}
+
+// GetName returns this measurement name.
+func (c *CycleErgometer) GetName() string {
+	return "Cycle ergometer"
+}
+
+// Result returns information about the cycle ergometer test.
+func (c *CycleErgometer) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := c.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	abs, err := c.Absolute()
+	if err != nil {
+		return rs, err
+	}
+
+	rs = append(
+		rs,
+		fmt.Sprintf("Protocol: %s", c.EquationConf.Name),
+		fmt.Sprintf("VO2max: %.2f L/min", abs),
+		fmt.Sprintf("VO2max: %.2f ml/kg/min", v),
+	)
+	return rs, nil
+}
+
+// Calc returns the estimated relative maximal oxygen uptake, in ml/kg/min.
+func (c *CycleErgometer) Calc() (float64, error) {
+	return c.equation().Calc()
+}
+
+// Absolute returns the estimated absolute maximal oxygen uptake, in L/min.
+func (c *CycleErgometer) Absolute() (float64, error) {
+	v, err := c.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	return v * c.Anthropometry.Weight / 1000, nil
+}
+
+// equation returns an equation, used to estimate maximal oxygen uptake.
+func (c *CycleErgometer) equation() Equationer {
+	return NewEquation(c.EquationConf.Extract(c), c.EquationConf)
+}
+
+/**
+ * Equations
+ */
+
+var (
+	astrandRyhmingConf = NewEquationConf(
+		"Åstrand-Ryhming",
+		func(i interface{}) InParams {
+			c := i.(*CycleErgometer)
+			r := map[string]float64{
+				"gender": float64(c.Person.Gender),
+				"age":    c.Person.AgeFromDate(c.Assessment.Date),
+				"weight": c.Anthropometry.Weight,
+			}
+			if n := len(c.Stages); n > 0 {
+				r["workload"] = c.Stages[n-1].Workload
+				r["heart rate"] = c.Stages[n-1].HeartRate
+			}
+			return r
+		},
+		[]Validator{
+			ValidateMeasures([]string{"gender", "age", "weight", "workload", "heart rate"}),
+			ValidateAge(15, 65),
+			ValidateRange("heart rate", 120, 170),
+		},
+		func(e *Equation) float64 {
+			gender, _ := e.In("gender")
+			age, _ := e.In("age")
+			weight, _ := e.In("weight")
+			workload, _ := e.In("workload")
+			hr, _ := e.In("heart rate")
+
+			work := workload * kgmPerWatt
+			vo2 := (0.00212*work + 0.299) / (0.769*hr - 48.5) * 100
+			if int(gender) == Female {
+				vo2 = (0.00193*work + 0.326) / (0.769*hr - 56.1) * 100
+			}
+			return vo2 * interpolate(age, astrandAgeFactor) * 1000 / weight
+		},
+	)
+	ymcaCycleConf = NewEquationConf(
+		"YMCA",
+		func(i interface{}) InParams {
+			c := i.(*CycleErgometer)
+			r := map[string]float64{
+				"age":    c.Person.AgeFromDate(c.Assessment.Date),
+				"weight": c.Anthropometry.Weight,
+			}
+			if n := len(c.Stages); n > 1 {
+				r["workload 1"] = c.Stages[n-2].Workload
+				r["heart rate 1"] = c.Stages[n-2].HeartRate
+				r["workload 2"] = c.Stages[n-1].Workload
+				r["heart rate 2"] = c.Stages[n-1].HeartRate
+			}
+			return r
+		},
+		[]Validator{
+			ValidateMeasures([]string{"age", "weight", "workload 1", "heart rate 1", "workload 2", "heart rate 2"}),
+			ValidateRange("heart rate 1", 110, 150),
+			ValidateRange("heart rate 2", 110, 150),
+			validateHeartRateIncrease,
+		},
+		func(e *Equation) float64 {
+			age, _ := e.In("age")
+			weight, _ := e.In("weight")
+			w1, _ := e.In("workload 1")
+			hr1, _ := e.In("heart rate 1")
+			w2, _ := e.In("workload 2")
+			hr2, _ := e.In("heart rate 2")
+
+			slope := (w2 - w1) / (hr2 - hr1)
+			workMax := w2 + slope*(220-age-hr2)
+			return 1.8*workMax*kgmPerWatt/weight + 7.0
+		},
+	)
+)
+
+// validateHeartRateIncrease ensure that heart rate increased between the two
+// stages used to extrapolate the workload at maximal heart rate.
+func validateHeartRateIncrease(e *Equation) (bool, error) {
+	hr1, _ := e.In("heart rate 1")
+	hr2, _ := e.In("heart rate 2")
+	if hr2 <= hr1 {
+		return false, fmt.Errorf("Heart rate must increase between stages")
+	}
+	return true, nil
+}
+
+// kgmPerWatt converts workload from watts to kgm/min.
+const kgmPerWatt = 6.12
+
+// astrandAgeFactor represents the age correction factor applied to the
+// Åstrand-Ryhming nomogram.
+var astrandAgeFactor = [][2]float64{
+	{15, 1.10},
+	{25, 1.00},
+	{35, 0.87},
+	{40, 0.83},
+	{45, 0.78},
+	{50, 0.75},
+	{55, 0.71},
+	{60, 0.68},
+	{65, 0.65},
+}
@@ -0,0 +1,91 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAstrandRyhmingCalc(t *testing.T) {
+	cases := []caseCycleErgometer{
+		newCaseCycleErgometer(male, "2003-Dec-15", 70, []Stage{{100, 125}, {150, 150}}, "male-age-25", 47.979, 3.3585, ""),
+		newCaseCycleErgometer(male, "2008-Dec-15", 70, []Stage{{150, 150}}, "male-age-30", 44.860, 3.1402, ""),
+		newCaseCycleErgometer(male, "2018-Dec-15", 70, []Stage{{150, 150}}, "male-age-40", 39.822, 2.7876, ""),
+		newCaseCycleErgometer(female, "2013-Mar-15", 60, []Stage{{100, 150}}, "female-age-25", 42.395, 2.5437, ""),
+		newCaseCycleErgometer(male, "2003-Dec-15", 70, []Stage{{50, 110}}, "heart rate too low", 0, 0, "Valid for heart rate"),
+		newCaseCycleErgometer(male, "2003-Dec-15", 70, []Stage{{250, 175}}, "heart rate too high", 0, 0, "Valid for heart rate"),
+		newCaseCycleErgometer(male, "1990-Dec-15", 70, []Stage{{150, 150}}, "too young", 0, 0, "Valid for ages"),
+		newCaseCycleErgometer(male, "2003-Dec-15", 70, []Stage{}, "without stages", 0, 0, "Missing workload"),
+	}
+
+	for _, data := range cases {
+		data.check(t, NewAstrandRyhming(data.person, data.assessment, data.anthropometry, data.stages...))
+	}
+}
+
+func TestYMCACycleCalc(t *testing.T) {
+	stages := []Stage{{25, 90}, {75, 115}, {100, 130}}
+	cases := []caseCycleErgometer{
+		newCaseCycleErgometer(male, "2018-Dec-15", 80, stages, "male-age-40", 32.245, 2.5796, ""),
+		newCaseCycleErgometer(male, "2018-Dec-15", 80, stages[:1], "single stage", 0, 0, "Missing workload 1"),
+		newCaseCycleErgometer(male, "2018-Dec-15", 80, []Stage{{75, 115}, {100, 155}}, "heart rate too high", 0, 0, "Valid for heart rate 2"),
+		newCaseCycleErgometer(male, "2018-Dec-15", 80, []Stage{{75, 130}, {100, 125}}, "heart rate decreased", 0, 0, "Heart rate must increase"),
+	}
+
+	for _, data := range cases {
+		data.check(t, NewYMCACycle(data.person, data.assessment, data.anthropometry, data.stages...))
+	}
+}
+
+type caseCycleErgometer struct {
+	person        *Person
+	assessment    *Assessment
+	anthropometry *Anthropometry
+	stages        []Stage
+	name          string
+	calc          float64
+	absolute      float64
+	err           string
+}
+
+func newCaseCycleErgometer(p *Person, d string, weight float64, stages []Stage, name string, calc, absolute float64, err string) caseCycleErgometer {
+	assessment, _ := NewAssessment(d)
+	return caseCycleErgometer{
+		person:        p,
+		assessment:    assessment,
+		anthropometry: NewAnthropometry(weight, 175),
+		stages:        stages,
+		name:          name,
+		calc:          calc,
+		absolute:      absolute,
+		err:           err,
+	}
+}
+
+func (data caseCycleErgometer) check(t *testing.T, c *CycleErgometer) {
+	calc, err := c.Calc()
+	if data.err != "" {
+		if err == nil {
+			t.Errorf("Case _%s_ failed, should show a validation error", data.name)
+		} else if !strings.Contains(err.Error(), data.err) {
+			t.Errorf("Case _%s_ failed, should show proper error message, got %s", data.name, err)
+		}
+		if _, err := c.Result(); err == nil {
+			t.Errorf("Case _%s_ failed, result should show a validation error", data.name)
+		}
+		return
+	}
+
+	if err != nil {
+		t.Errorf("Case _%s_ failed, unexpected error %s", data.name, err)
+	} else if !floatEqual(calc, data.calc, 0.001) {
+		t.Errorf("Case _%s_ failed, should have value %.4f, instead got %.4f", data.name, data.calc, calc)
+	}
+
+	if abs, _ := c.Absolute(); !floatEqual(abs, data.absolute, 0.0001) {
+		t.Errorf("Case _%s_ failed, should have absolute value %.4f, instead got %.4f", data.name, data.absolute, abs)
+	}
+
+	if rs, err := c.Result(); err != nil || len(rs) != 3 {
+		t.Errorf("Case _%s_ failed, should have a result, instead got %v", data.name, err)
+	}
+}
@@ -0,0 +1,388 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/**
+ * Constants
+ */
+
+// FITNESSGRAM items constants.
+const (
+	// FGPacer: PACER 20 m shuttle run, in laps.
+	FGPacer int = iota
+	// FGPushUp: 90 degrees push-up, in repetitions.
+	FGPushUp
+	// FGCurlUp: curl-up, in repetitions.
+	FGCurlUp
+	// FGTrunkLift: trunk lift, in cm.
+	FGTrunkLift
+	// FGBodyComposition: body fat percentage, estimated from skinfolds.
+	FGBodyComposition
+)
+
+/**
+ * FITNESSGRAM
+ */
+
+// Fitnessgram represents the FITNESSGRAM youth fitness battery, with each item
+// placed into the Healthy Fitness Zone by age and gender. Body composition is
+// estimated with Slaughter et al. equations from triceps and calf skinfolds.
+type Fitnessgram struct {
+	*Person
+	*Assessment
+	*Anthropometry
+	*Skinfolds
+	Items map[int]float64
+}
+
+// NewFitnessgram creates a new FITNESSGRAM battery, based in person,
+// assessment, anthropometry, skinfolds, and items measures.
+func NewFitnessgram(person *Person, assessment *Assessment, anthropometry *Anthropometry, skinfolds *Skinfolds, items map[int]float64) *Fitnessgram {
+	return &Fitnessgram{person, assessment, anthropometry, skinfolds, items}
+}
+
+func (f *Fitnessgram) String() string {
+	v, _ := f.Calc()
+	return fmt.Sprintf("FITNESSGRAM: %.0f items in Healthy Fitness Zone", v)
+}
+
+// GetName returns this measurement name.
+func (f *Fitnessgram) GetName() string {
+	return "FITNESSGRAM"
+}
+
+// Result returns each item measured with its zone, and the number of items
+// in the Healthy Fitness Zone.
+func (f *Fitnessgram) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := f.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	for _, item := range f.items() {
+		value, err := f.Value(item)
+		if err != nil {
+			return []string{}, err
+		}
+		c, err := f.ClassifyItem(item)
+		if err != nil {
+			return []string{}, err
+		}
+		rs = append(rs, fmt.Sprintf("%s: %.1f %s (%s).", NamedFitnessgramItem(item), value, fitnessgramUnits[item], c))
+	}
+
+	rs = append(rs, fmt.Sprintf("Items in Healthy Fitness Zone: %.0f of %d.", v, len(f.items())))
+	return rs, nil
+}
+
+// VO2max returns the maximal oxygen uptake, in ml/kg/min, estimated from
+// PACER laps with the equation from Mahar et al.
+func (f *Fitnessgram) VO2max() (float64, error) {
+	return NewEquation(pacerConf.Extract(f), pacerConf).Calc()
+}
+
+// BodyFat returns the body fat percentage, estimated with Slaughter et al.
+// equations.
+func (f *Fitnessgram) BodyFat() (float64, error) {
+	if f.Skinfolds == nil {
+		return 0.0, fmt.Errorf("Missing skinfolds")
+	}
+	if f.Person.Gender == Female {
+		return NewWomenSlaughterSKF(f.Person, f.Assessment, f.Skinfolds).Calc()
+	}
+	return NewMenSlaughterSKF(f.Person, f.Assessment, f.Skinfolds).Calc()
+}
+
+// Value returns the value used to classify a given item. PACER is converted
+// to maximal oxygen uptake, body composition to body fat percentage, and
+// trunk lift is capped at 12 inches, as no credit is given above it.
+func (f *Fitnessgram) Value(item int) (float64, error) {
+	switch item {
+	case FGPacer:
+		return f.VO2max()
+	case FGBodyComposition:
+		return f.BodyFat()
+	}
+	v, ok := f.Items[item]
+	if !ok {
+		return 0.0, fmt.Errorf("Missing %s measure", NamedFitnessgramItem(item))
+	}
+	if item == FGTrunkLift {
+		return math.Min(v, trunkLiftCap), nil
+	}
+	return v, nil
+}
+
+// Zone returns the zone constant for a given item.
+func (f *Fitnessgram) Zone(item int) (int, error) {
+	table, ok := fitnessgramLimits[item]
+	if !ok {
+		return -1, fmt.Errorf("No classification for item %d", item)
+	}
+
+	v, err := f.Value(item)
+	if err != nil {
+		return -1, err
+	}
+
+	classes, err := limitsForGenderAndAge(table, f.Person.Gender, f.Person.AgeFromDate(f.Assessment.Date))
+	if err != nil {
+		return -1, err
+	}
+
+	return classifierIndex(v, classes), nil
+}
+
+// ClassifyItem returns the zone classification for a given item.
+func (f *Fitnessgram) ClassifyItem(item int) (string, error) {
+	zone, err := f.Zone(item)
+	if err != nil {
+		return "", err
+	}
+	return HFZClassification[zone], nil
+}
+
+// Calc returns the number of items in the Healthy Fitness Zone.
+func (f *Fitnessgram) Calc() (float64, error) {
+	items := f.items()
+	if len(items) == 0 {
+		return 0.0, fmt.Errorf("Missing FITNESSGRAM items")
+	}
+
+	count := 0.0
+	for _, item := range items {
+		zone, err := f.Zone(item)
+		if err != nil {
+			return 0.0, err
+		}
+		if zone == HFZHealthy {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// items returns the items measured, including body composition when
+// skinfolds are available.
+func (f *Fitnessgram) items() []int {
+	items := []int{}
+	for k := range f.Items {
+		if _, ok := fitnessgramLimits[k]; ok && k != FGBodyComposition {
+			items = append(items, k)
+		}
+	}
+	if f.Skinfolds != nil {
+		items = append(items, FGBodyComposition)
+	}
+	sort.Ints(items)
+	return items
+}
+
+// NamedFitnessgramItem returns the name for a given FITNESSGRAM item
+// constant.
+func NamedFitnessgramItem(item int) string {
+	named := map[int]string{
+		FGPacer:           "PACER aerobic capacity",
+		FGPushUp:          "push-up",
+		FGCurlUp:          "curl-up",
+		FGTrunkLift:       "trunk lift",
+		FGBodyComposition: "body fat",
+	}
+	return named[item]
+}
+
+// fitnessgramUnits maps each item to the unit of the value classified.
+var fitnessgramUnits = map[int]string{
+	FGPacer:           "ml/kg/min",
+	FGPushUp:          "repetitions",
+	FGCurlUp:          "repetitions",
+	FGTrunkLift:       "cm",
+	FGBodyComposition: "%",
+}
+
+/**
+ * Equation
+ */
+
+var pacerConf = NewEquationConf(
+	"PACER from Mahar et al.",
+	func(i interface{}) InParams {
+		f := i.(*Fitnessgram)
+		r := map[string]float64{
+			"age":    f.Person.AgeFromDate(f.Assessment.Date),
+			"gender": float64(f.Person.Gender),
+		}
+		if f.Anthropometry != nil {
+			r["weight"] = f.Anthropometry.Weight
+			r["height"] = f.Anthropometry.Height
+		}
+		if v, ok := f.Items[FGPacer]; ok {
+			r["laps"] = v
+		}
+		return r
+	},
+	[]Validator{
+		ValidateMeasures([]string{"age", "gender", "weight", "height", "laps"}),
+		ValidateAge(10, 17),
+	},
+	func(e *Equation) float64 {
+		age, _ := e.In("age")
+		gender, _ := e.In("gender")
+		laps, _ := e.In("laps")
+		bmi := bmiConf.Calc(e)
+		boy := 0.0
+		if int(gender) == Male {
+			boy = 1.0
+		}
+		return 41.76799 + 0.49261*laps - 0.00290*math.Pow(laps, 2) - 0.61613*bmi + 0.34787*boy*age
+	},
+)
+
+/**
+ * Classification
+ */
+
+// newYearlyLimits returns classification limits for consecutive one year age
+// bands, starting at a given age.
+func newYearlyLimits(start float64, limits ...map[int][2]float64) map[[2]float64]map[int][2]float64 {
+	rs := map[[2]float64]map[int][2]float64{}
+	for i, l := range limits {
+		lower := start + float64(i)
+		rs[[2]float64{lower, lower + 1}] = l
+	}
+	return rs
+}
+
+// newAerobicLimits returns three zone limits for aerobic capacity, where the
+// health risk zone is up to risk, and the healthy zone starts at healthy.
+func newAerobicLimits(risk, healthy float64) map[int][2]float64 {
+	r := math.Nextafter(risk, math.Inf(+1))
+	return map[int][2]float64{
+		HFZHealthRisk:       {math.Inf(-1), r},
+		HFZNeedsImprovement: {r, healthy},
+		HFZHealthy:          {healthy, math.Inf(+1)},
+	}
+}
+
+// newBodyFatLimits returns three zone limits for body fat, where the healthy
+// zone is up to healthy, and the health risk zone starts at risk.
+func newBodyFatLimits(healthy, risk float64) map[int][2]float64 {
+	h := math.Nextafter(healthy, math.Inf(+1))
+	return map[int][2]float64{
+		HFZHealthy:          {math.Inf(-1), h},
+		HFZNeedsImprovement: {h, risk},
+		HFZHealthRisk:       {risk, math.Inf(+1)},
+	}
+}
+
+// trunkLiftCap is the highest trunk lift credited, in cm.
+const trunkLiftCap = 12 * inch
+
+// fitnessgramLimits represent the Healthy Fitness Zone standards for each
+// item, gender and age.
+var fitnessgramLimits = map[int]map[int]map[[2]float64]map[int][2]float64{
+	FGPacer: {
+		Male: newYearlyLimits(
+			10,
+			newAerobicLimits(37.3, 40.2),
+			newAerobicLimits(37.3, 40.2),
+			newAerobicLimits(37.6, 40.3),
+			newAerobicLimits(38.6, 41.1),
+			newAerobicLimits(39.6, 42.5),
+			newAerobicLimits(40.6, 43.6),
+			newAerobicLimits(41.0, 44.1),
+			newAerobicLimits(41.2, 44.2),
+		),
+		Female: newYearlyLimits(
+			10,
+			newAerobicLimits(37.3, 40.2),
+			newAerobicLimits(37.3, 40.2),
+			newAerobicLimits(37.0, 40.1),
+			newAerobicLimits(36.6, 39.7),
+			newAerobicLimits(36.3, 39.4),
+			newAerobicLimits(36.0, 39.1),
+			newAerobicLimits(35.8, 38.9),
+			newAerobicLimits(35.7, 38.8),
+		),
+	},
+	FGPushUp: {
+		Male: newYearlyLimits(
+			5,
+			newHFZLimits(3), newHFZLimits(3), newHFZLimits(4), newHFZLimits(5),
+			newHFZLimits(6), newHFZLimits(7), newHFZLimits(8), newHFZLimits(10),
+			newHFZLimits(12), newHFZLimits(14), newHFZLimits(16), newHFZLimits(18),
+			newHFZLimits(18),
+		),
+		Female: newYearlyLimits(
+			5,
+			newHFZLimits(3), newHFZLimits(3), newHFZLimits(4), newHFZLimits(5),
+			newHFZLimits(6), newHFZLimits(7), newHFZLimits(7), newHFZLimits(7),
+			newHFZLimits(7), newHFZLimits(7), newHFZLimits(7), newHFZLimits(7),
+			newHFZLimits(7),
+		),
+	},
+	FGCurlUp: {
+		Male: newYearlyLimits(
+			5,
+			newHFZLimits(2), newHFZLimits(2), newHFZLimits(4), newHFZLimits(6),
+			newHFZLimits(9), newHFZLimits(12), newHFZLimits(15), newHFZLimits(18),
+			newHFZLimits(21), newHFZLimits(24), newHFZLimits(24), newHFZLimits(24),
+			newHFZLimits(24),
+		),
+		Female: newYearlyLimits(
+			5,
+			newHFZLimits(2), newHFZLimits(2), newHFZLimits(4), newHFZLimits(6),
+			newHFZLimits(9), newHFZLimits(12), newHFZLimits(15), newHFZLimits(18),
+			newHFZLimits(18), newHFZLimits(18), newHFZLimits(18), newHFZLimits(18),
+			newHFZLimits(18),
+		),
+	},
+	FGTrunkLift: {
+		Male: {
+			{5, 10}:  newHFZLimits(6 * inch),
+			{10, 18}: newHFZLimits(9 * inch),
+		},
+		Female: {
+			{5, 10}:  newHFZLimits(6 * inch),
+			{10, 18}: newHFZLimits(9 * inch),
+		},
+	},
+	FGBodyComposition: {
+		Male: newYearlyLimits(
+			6,
+			newBodyFatLimits(18.8, 27.0),
+			newBodyFatLimits(18.8, 27.0),
+			newBodyFatLimits(18.8, 27.0),
+			newBodyFatLimits(20.6, 30.1),
+			newBodyFatLimits(22.4, 33.2),
+			newBodyFatLimits(23.6, 35.4),
+			newBodyFatLimits(23.6, 35.9),
+			newBodyFatLimits(22.8, 35.0),
+			newBodyFatLimits(21.3, 33.2),
+			newBodyFatLimits(20.1, 31.5),
+			newBodyFatLimits(20.1, 31.6),
+			newBodyFatLimits(20.9, 33.0),
+		),
+		Female: newYearlyLimits(
+			6,
+			newBodyFatLimits(20.8, 28.4),
+			newBodyFatLimits(20.8, 28.4),
+			newBodyFatLimits(20.8, 28.4),
+			newBodyFatLimits(22.6, 30.8),
+			newBodyFatLimits(24.3, 33.0),
+			newBodyFatLimits(25.7, 34.5),
+			newBodyFatLimits(26.7, 35.5),
+			newBodyFatLimits(27.7, 36.3),
+			newBodyFatLimits(28.5, 36.8),
+			newBodyFatLimits(29.1, 37.1),
+			newBodyFatLimits(29.7, 37.4),
+			newBodyFatLimits(30.4, 37.9),
+		),
+	},
+}
@@ -0,0 +1,101 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFitnessgramZones(t *testing.T) {
+	boyAssessment, _ := NewAssessment("1990-Dec-15")
+	girlAssessment, _ := NewAssessment("2000-Mar-15")
+
+	boy := NewFitnessgram(
+		male,
+		boyAssessment,
+		NewAnthropometry(40, 150),
+		NewSkinfolds(map[int]float64{SKFTriceps: 10, SKFCalf: 8}),
+		map[int]float64{FGPacer: 40, FGPushUp: 8, FGCurlUp: 20, FGTrunkLift: 25},
+	)
+	girl := NewFitnessgram(
+		female,
+		girlAssessment,
+		NewAnthropometry(45, 150),
+		NewSkinfolds(map[int]float64{SKFTriceps: 25, SKFCalf: 22}),
+		map[int]float64{FGPacer: 15, FGPushUp: 7},
+	)
+	tenAssessment, _ := NewAssessment("1988-Dec-20")
+	ten := NewFitnessgram(male, tenAssessment, nil, nil, map[int]float64{FGTrunkLift: 8 * inch})
+	stretched := NewFitnessgram(male, boyAssessment, nil, nil, map[int]float64{FGTrunkLift: 13 * inch})
+
+	type itemCase struct {
+		fitnessgram *Fitnessgram
+		item        int
+		value       float64
+		zone        int
+	}
+
+	cases := []itemCase{
+		{boy, FGPacer, 50.053, HFZHealthy},
+		{boy, FGPushUp, 8, HFZNeedsImprovement},
+		{boy, FGCurlUp, 20, HFZHealthy},
+		{boy, FGTrunkLift, 25, HFZHealthy},
+		{boy, FGBodyComposition, 14.23, HFZHealthy},
+		{ten, FGTrunkLift, 20.32, HFZNeedsImprovement},
+		{stretched, FGTrunkLift, 30.48, HFZHealthy},
+		{girl, FGPacer, 36.182, HFZHealthRisk},
+		{girl, FGPushUp, 7, HFZHealthy},
+		{girl, FGBodyComposition, 33.77, HFZNeedsImprovement},
+	}
+
+	for _, data := range cases {
+		name := NamedFitnessgramItem(data.item)
+		if v, err := data.fitnessgram.Value(data.item); err != nil {
+			t.Errorf("Item %s should not get an error: %s", name, err)
+		} else if !floatEqual(v, data.value, 0.001) {
+			t.Errorf("Item %s value is %.3f, expected is %.3f", name, v, data.value)
+		}
+		if zone, _ := data.fitnessgram.Zone(data.item); zone != data.zone {
+			t.Errorf("Item %s zone is %s, expected is %s", name, HFZClassification[zone], HFZClassification[data.zone])
+		}
+	}
+
+	if v, err := boy.Calc(); err != nil || v != 4 {
+		t.Errorf("Boy should have 4 items in HFZ, got %.0f (%v)", v, err)
+	}
+	if rs, err := boy.Result(); err != nil || len(rs) != 6 {
+		t.Errorf("Should have a result, instead got %v", err)
+	}
+	if v, err := girl.Calc(); err != nil || v != 1 {
+		t.Errorf("Girl should have 1 item in HFZ, got %.0f (%v)", v, err)
+	}
+}
+
+func TestFitnessgramInvalid(t *testing.T) {
+	young, _ := NewAssessment("1986-Dec-15")
+	adult, _ := NewAssessment("2015-May-22")
+
+	type fitnessgramCase struct {
+		fitnessgram *Fitnessgram
+		item        int
+		err         string
+	}
+
+	cases := []fitnessgramCase{
+		{NewFitnessgram(male, young, NewAnthropometry(30, 135), nil, map[int]float64{FGPacer: 20}), FGPacer, "Valid for ages"},
+		{NewFitnessgram(male, young, nil, nil, map[int]float64{FGCurlUp: 10}), FGPushUp, "Missing push-up"},
+		{NewFitnessgram(male, young, nil, nil, map[int]float64{FGCurlUp: 10}), FGBodyComposition, "Missing skinfolds"},
+		{NewFitnessgram(male, adult, nil, nil, map[int]float64{FGCurlUp: 10}), FGCurlUp, "No classification for age"},
+		{NewFitnessgram(male, young, nil, nil, map[int]float64{}), -1, "No classification for item"},
+	}
+
+	for _, data := range cases {
+		if _, err := data.fitnessgram.ClassifyItem(data.item); err == nil || !strings.Contains(err.Error(), data.err) {
+			t.Errorf("Should show proper error message %s, got %v", data.err, err)
+		}
+	}
+
+	empty := NewFitnessgram(male, young, nil, nil, map[int]float64{})
+	if _, err := empty.Result(); err == nil || !strings.Contains(err.Error(), "Missing FITNESSGRAM items") {
+		t.Errorf("Result should show proper error message, got %v", err)
+	}
+}
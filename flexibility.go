@@ -0,0 +1,399 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Constants
+ */
+
+// Leg constants.
+const (
+	// LegRight: right leg.
+	LegRight int = iota
+	// LegLeft: left leg.
+	LegLeft
+)
+
+/**
+ * Flexibility tests
+ */
+
+// Flexibility tests with their normative classification.
+var (
+	NewSitAndReach = FactoryFlexibility(confSitAndReach)
+	NewWellsBench  = FactoryFlexibility(confWellsBench)
+	NewVSit        = FactoryFlexibility(confVSit)
+)
+
+// Flexibility tests measured in each leg, with their normative
+// classification.
+var (
+	NewBackSaverSitAndReach = FactoryLegsFlexibility(confBackSaverSitAndReach)
+)
+
+/**
+ * Flexibility
+ */
+
+// Flexibility contains data needed to assess flexibility with a reach test.
+// This is a composition of a person, assessment details, the trials performed
+// (in cm) and the test configuration. The best trial is used as result. Tests
+// measured in each leg keep the trials by leg, and the best trial of the
+// worst leg is used as result.
+type Flexibility struct {
+	*Person
+	*Assessment
+	Trials []float64
+	Legs   map[int][]float64
+	*EquationConf
+	limits map[int]map[[2]float64]map[int][2]float64
+	mapper map[int]string
+}
+
+// FactoryFlexibility factory to create new flexibility tests. It returns a
+// function to create new Flexibility structs.
+func FactoryFlexibility(conf FlexibilityConf) func(*Person, *Assessment, ...float64) *Flexibility {
+	c := NewEquationConfForFlexibility(conf)
+	return func(p *Person, a *Assessment, trials ...float64) *Flexibility {
+		return &Flexibility{Person: p, Assessment: a, Trials: trials, EquationConf: c, limits: conf.limits, mapper: conf.mapper}
+	}
+}
+
+// FactoryLegsFlexibility factory to create new flexibility tests measured in
+// each leg. It returns a function to create new Flexibility structs, from the
+// trials for each leg.
+func FactoryLegsFlexibility(conf FlexibilityConf) func(*Person, *Assessment, map[int][]float64) *Flexibility {
+	c := NewEquationConfForFlexibility(conf)
+	return func(p *Person, a *Assessment, legs map[int][]float64) *Flexibility {
+		return &Flexibility{Person: p, Assessment: a, Legs: legs, EquationConf: c, limits: conf.limits, mapper: conf.mapper}
+	}
+}
+
+func (f *Flexibility) String() string {
+	v, _ := f.Calc()
+	c, _ := f.Classify()
+	return fmt.Sprintf("%s: %.1f cm (%s)", f.EquationConf.Name, v, c)
+}
+
+// GetName returns this measurement name.
+func (f *Flexibility) GetName() string {
+	return "Flexibility"
+}
+
+// Result returns relevant information about flexibility assessment.
+func (f *Flexibility) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := f.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	c, err := f.Classify()
+	if err != nil {
+		return rs, err
+	}
+
+	if f.Legs != nil {
+		for _, leg := range []int{LegRight, LegLeft} {
+			lv, _ := f.Leg(leg)
+			lc, _ := f.ClassifyLeg(leg)
+			rs = append(rs, fmt.Sprintf("%s %s: %.1f cm (%s).", f.EquationConf.Name, NamedLeg(leg), lv, lc))
+		}
+		return rs, nil
+	}
+
+	rs = append(
+		rs,
+		fmt.Sprintf("%s: %.1f cm (best of %d trials).", f.EquationConf.Name, v, len(f.Trials)),
+		fmt.Sprintf("%s classification: %s.", f.EquationConf.Name, c),
+	)
+	return rs, nil
+}
+
+// Classify returns the normative classification for the best trial. For
+// tests measured in each leg, the worst leg is classified.
+func (f *Flexibility) Classify() (string, error) {
+	v, err := f.Calc()
+	if err != nil {
+		return "", err
+	}
+	return f.classify(v)
+}
+
+// Leg returns the best trial, in cm, for a given leg.
+func (f *Flexibility) Leg(leg int) (float64, error) {
+	trials := f.Legs[leg]
+	if len(trials) == 0 {
+		return 0.0, fmt.Errorf("Missing %s trials", NamedLeg(leg))
+	}
+	best := math.Inf(-1)
+	for _, v := range trials {
+		best = math.Max(best, v)
+	}
+	return best, nil
+}
+
+// ClassifyLeg returns the normative classification for the best trial of a
+// given leg.
+func (f *Flexibility) ClassifyLeg(leg int) (string, error) {
+	if _, err := f.Calc(); err != nil {
+		return "", err
+	}
+	v, err := f.Leg(leg)
+	if err != nil {
+		return "", err
+	}
+	return f.classify(v)
+}
+
+// classify returns the normative classification for a given value.
+func (f *Flexibility) classify(v float64) (string, error) {
+	classes, err := limitsForGenderAndAge(f.limits, f.Person.Gender, f.Person.AgeFromDate(f.Assessment.Date))
+	if err != nil {
+		return "", err
+	}
+
+	return Classifier(v, classes, f.mapper), nil
+}
+
+// Calc returns the best trial, in cm.
+func (f *Flexibility) Calc() (float64, error) {
+	return f.equation().Calc()
+}
+
+// equation returns an equation, used to select the best trial.
+func (f *Flexibility) equation() Equationer {
+	return NewEquation(f.EquationConf.Extract(f), f.EquationConf)
+}
+
+// NamedLeg returns the name for a given leg constant.
+func NamedLeg(leg int) string {
+	named := map[int]string{
+		LegRight: "right leg",
+		LegLeft:  "left leg",
+	}
+	return named[leg]
+}
+
+/**
+ * Flexibility conf definition
+ */
+
+// Popular flexibility tests and their normative tables.
+var (
+	confSitAndReach = FlexibilityConf{
+		name:   "Sit-and-reach",
+		limits: sitAndReachLimits,
+		mapper: FitnessClassification,
+	}
+	confVSit = FlexibilityConf{
+		name:   "V-sit reach",
+		limits: vSitLimits,
+		mapper: PercentileClassification,
+	}
+	confBackSaverSitAndReach = FlexibilityConf{
+		name:   "Back-saver sit-and-reach",
+		legs:   true,
+		limits: backSaverLimits,
+		mapper: HFZClassification,
+	}
+)
+
+// The Canadian standardized test is performed in a Wells and Dillon bench,
+// sharing the same protocol and normative table of sit-and-reach.
+var confWellsBench = func() FlexibilityConf {
+	conf := confSitAndReach
+	conf.name = "Wells bench sit-and-reach"
+	return conf
+}()
+
+/**
+ * Flexibility conf
+ */
+
+// NewEquationConfForFlexibility returns an equation configuration based in
+// provided configuration.
+func NewEquationConfForFlexibility(conf FlexibilityConf) *EquationConf {
+	if conf.legs {
+		return newEquationConfForLegsFlexibility(conf)
+	}
+	extractor := func(i interface{}) InParams {
+		f := i.(*Flexibility)
+		r := map[string]float64{}
+		if len(f.Trials) > 0 {
+			best := math.Inf(-1)
+			for _, v := range f.Trials {
+				best = math.Max(best, v)
+			}
+			r["best"] = best
+		}
+		return r
+	}
+	validators := []Validator{
+		ValidateMeasures([]string{"best"}),
+	}
+	return NewEquationConf(conf.name, extractor, validators, func(e *Equation) float64 {
+		v, _ := e.In("best")
+		return v
+	})
+}
+
+// newEquationConfForLegsFlexibility returns an equation configuration for
+// tests measured in each leg, where the worst leg is used.
+func newEquationConfForLegsFlexibility(conf FlexibilityConf) *EquationConf {
+	extractor := func(i interface{}) InParams {
+		f := i.(*Flexibility)
+		r := map[string]float64{}
+		for _, leg := range []int{LegRight, LegLeft} {
+			if v, err := f.Leg(leg); err == nil {
+				r[NamedLeg(leg)] = v
+			}
+		}
+		return r
+	}
+	validators := []Validator{
+		ValidateMeasures([]string{NamedLeg(LegRight), NamedLeg(LegLeft)}),
+	}
+	return NewEquationConf(conf.name, extractor, validators, func(e *Equation) float64 {
+		right, _ := e.In(NamedLeg(LegRight))
+		left, _ := e.In(NamedLeg(LegLeft))
+		return math.Min(right, left)
+	})
+}
+
+// FlexibilityConf common configuration for flexibility tests. Tests measured
+// in each leg are flagged by legs.
+type FlexibilityConf struct {
+	name   string
+	legs   bool
+	limits map[int]map[[2]float64]map[int][2]float64
+	mapper map[int]string
+}
+
+/**
+ * Classification
+ */
+
+// Percentile band classification constants.
+const (
+	PercentileBelow50 = iota
+	Percentile50To85
+	PercentileAbove85
+)
+
+// PercentileClassification map percentile band constants to their string
+// representation.
+var PercentileClassification = map[int]string{
+	PercentileBelow50: "Below 50th percentile",
+	Percentile50To85:  "Between 50th and 85th percentile",
+	PercentileAbove85: "85th percentile or above",
+}
+
+// Healthy Fitness Zone classification constants.
+const (
+	HFZHealthRisk = iota
+	HFZNeedsImprovement
+	HFZHealthy
+)
+
+// HFZClassification map Healthy Fitness Zone constants to their string
+// representation.
+var HFZClassification = map[int]string{
+	HFZHealthRisk:       "Needs Improvement - Health Risk",
+	HFZNeedsImprovement: "Needs Improvement",
+	HFZHealthy:          "Healthy Fitness Zone",
+}
+
+// inch converts inches to centimeters.
+const inch = 2.54
+
+// newPercentileLimits returns percentile band limits, based in the 50th and
+// 85th percentiles.
+func newPercentileLimits(p50, p85 float64) map[int][2]float64 {
+	return map[int][2]float64{
+		PercentileBelow50: {math.Inf(-1), p50},
+		Percentile50To85:  {p50, p85},
+		PercentileAbove85: {p85, math.Inf(+1)},
+	}
+}
+
+// newHFZLimits returns Healthy Fitness Zone limits, based in the lower limit
+// for the healthy zone.
+func newHFZLimits(healthy float64) map[int][2]float64 {
+	return map[int][2]float64{
+		HFZNeedsImprovement: {math.Inf(-1), healthy},
+		HFZHealthy:          {healthy, math.Inf(+1)},
+	}
+}
+
+// sitAndReachLimits represent the classification limits for sit-and-reach,
+// with foot line at 26 cm, from the Canadian Physical Activity, Fitness and
+// Lifestyle Approach.
+var sitAndReachLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{15, 20}: newFitnessLimits(24, 29, 34, 39),
+		{20, 30}: newFitnessLimits(25, 30, 34, 40),
+		{30, 40}: newFitnessLimits(23, 28, 33, 38),
+		{40, 50}: newFitnessLimits(18, 24, 29, 35),
+		{50, 60}: newFitnessLimits(16, 24, 28, 35),
+		{60, 70}: newFitnessLimits(15, 20, 25, 33),
+	},
+	Female: {
+		{15, 20}: newFitnessLimits(29, 34, 38, 43),
+		{20, 30}: newFitnessLimits(28, 33, 37, 41),
+		{30, 40}: newFitnessLimits(27, 32, 36, 41),
+		{40, 50}: newFitnessLimits(25, 30, 34, 38),
+		{50, 60}: newFitnessLimits(25, 30, 33, 39),
+		{60, 70}: newFitnessLimits(23, 27, 31, 35),
+	},
+}
+
+// vSitLimits represent the 50th and 85th percentiles for V-sit reach, from
+// the President's Challenge youth fitness test.
+var vSitLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{6, 7}:   newPercentileLimits(1.0*inch, 3.5*inch),
+		{7, 8}:   newPercentileLimits(1.0*inch, 3.5*inch),
+		{8, 9}:   newPercentileLimits(0.5*inch, 3.0*inch),
+		{9, 10}:  newPercentileLimits(1.0*inch, 3.0*inch),
+		{10, 11}: newPercentileLimits(1.0*inch, 4.0*inch),
+		{11, 12}: newPercentileLimits(1.0*inch, 4.0*inch),
+		{12, 13}: newPercentileLimits(1.0*inch, 4.0*inch),
+		{13, 14}: newPercentileLimits(0.5*inch, 3.5*inch),
+		{14, 15}: newPercentileLimits(1.0*inch, 4.5*inch),
+		{15, 16}: newPercentileLimits(2.0*inch, 5.0*inch),
+		{16, 17}: newPercentileLimits(3.0*inch, 6.0*inch),
+		{17, 18}: newPercentileLimits(3.0*inch, 7.0*inch),
+	},
+	Female: {
+		{6, 7}:   newPercentileLimits(2.5*inch, 5.5*inch),
+		{7, 8}:   newPercentileLimits(2.0*inch, 5.0*inch),
+		{8, 9}:   newPercentileLimits(2.0*inch, 4.5*inch),
+		{9, 10}:  newPercentileLimits(2.0*inch, 5.5*inch),
+		{10, 11}: newPercentileLimits(3.0*inch, 6.0*inch),
+		{11, 12}: newPercentileLimits(3.0*inch, 6.5*inch),
+		{12, 13}: newPercentileLimits(3.5*inch, 7.0*inch),
+		{13, 14}: newPercentileLimits(3.5*inch, 7.0*inch),
+		{14, 15}: newPercentileLimits(4.5*inch, 8.0*inch),
+		{15, 16}: newPercentileLimits(5.0*inch, 8.0*inch),
+		{16, 17}: newPercentileLimits(5.5*inch, 9.0*inch),
+		{17, 18}: newPercentileLimits(4.5*inch, 8.0*inch),
+	},
+}
+
+// backSaverLimits represent the Healthy Fitness Zone for back-saver
+// sit-and-reach, measured in each leg, from FITNESSGRAM.
+var backSaverLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{5, 18}: newHFZLimits(8 * inch),
+	},
+	Female: {
+		{5, 11}:  newHFZLimits(9 * inch),
+		{11, 15}: newHFZLimits(10 * inch),
+		{15, 18}: newHFZLimits(12 * inch),
+	},
+}
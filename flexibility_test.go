@@ -0,0 +1,117 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlexibilityCalcAndClassification(t *testing.T) {
+	type flexibilitySpec struct {
+		factory    func(*Person, *Assessment, ...float64) *Flexibility
+		person     *Person
+		assessment string
+		trials     []float64
+		calc       float64
+		classify   string
+	}
+
+	specs := []flexibilitySpec{
+		{NewSitAndReach, male, "2015-May-22", []float64{30, 32, 31}, 32, FitnessClassification[FitnessGood]},
+		{NewSitAndReach, male, "2015-May-22", []float64{20.5}, 20.5, FitnessClassification[FitnessNeedsImprovement]},
+		{NewSitAndReach, female, "2015-May-22", []float64{40, 42}, 42, FitnessClassification[FitnessExcellent]},
+		{NewWellsBench, male, "2045-May-22", []float64{26, 24}, 26, FitnessClassification[FitnessVeryGood]},
+		{NewWellsBench, female, "2045-May-22", []float64{28}, 28, FitnessClassification[FitnessFair]},
+		{NewVSit, male, "1988-Dec-15", []float64{5, 4}, 5, PercentileClassification[Percentile50To85]},
+		{NewVSit, male, "1988-Dec-15", []float64{11}, 11, PercentileClassification[PercentileAbove85]},
+		{NewVSit, female, "2000-Mar-15", []float64{-2}, -2, PercentileClassification[PercentileBelow50]},
+	}
+
+	for _, spec := range specs {
+		a, _ := NewAssessment(spec.assessment)
+		f := spec.factory(spec.person, a, spec.trials...)
+
+		if calc, err := f.Calc(); err != nil {
+			t.Errorf("%s should not get an error: %s", f.EquationConf.Name, err)
+		} else if !floatEqual(calc, spec.calc, 0.001) {
+			t.Errorf("%s calc is %.2f, expected is %.2f", f.EquationConf.Name, calc, spec.calc)
+		}
+		if classify, _ := f.Classify(); classify != spec.classify {
+			t.Errorf("%s classify is %s, expected is %s", f.EquationConf.Name, classify, spec.classify)
+		}
+		if rs, err := f.Result(); err != nil || len(rs) != 2 {
+			t.Errorf("%s should have a result, instead got %v", f.EquationConf.Name, err)
+		}
+	}
+
+	a, _ := NewAssessment("2015-May-22")
+	if name := NewWellsBench(male, a, 30).EquationConf.Name; name == NewSitAndReach(male, a, 30).EquationConf.Name {
+		t.Errorf("Wells bench should have its own name, got %s", name)
+	}
+}
+
+func TestBackSaverSitAndReach(t *testing.T) {
+	type backSaverSpec struct {
+		person     *Person
+		assessment string
+		legs       map[int][]float64
+		calc       float64
+		right      string
+		left       string
+		classify   string
+	}
+
+	healthy, needsImprovement := HFZClassification[HFZHealthy], HFZClassification[HFZNeedsImprovement]
+	specs := []backSaverSpec{
+		{female, "2000-Mar-15", map[int][]float64{LegRight: {24, 26}, LegLeft: {27}}, 26, healthy, healthy, healthy},
+		{female, "2000-Mar-15", map[int][]float64{LegRight: {26}, LegLeft: {20, 18}}, 20, healthy, needsImprovement, needsImprovement},
+		{male, "1988-Dec-15", map[int][]float64{LegRight: {21}, LegLeft: {22}}, 21, healthy, healthy, healthy},
+	}
+
+	for _, spec := range specs {
+		a, _ := NewAssessment(spec.assessment)
+		f := NewBackSaverSitAndReach(spec.person, a, spec.legs)
+
+		if calc, err := f.Calc(); err != nil {
+			t.Errorf("Should not get an error: %s", err)
+		} else if !floatEqual(calc, spec.calc, 0.001) {
+			t.Errorf("Calc is %.2f, expected is %.2f", calc, spec.calc)
+		}
+		if classify, _ := f.ClassifyLeg(LegRight); classify != spec.right {
+			t.Errorf("Right leg classify is %s, expected is %s", classify, spec.right)
+		}
+		if classify, _ := f.ClassifyLeg(LegLeft); classify != spec.left {
+			t.Errorf("Left leg classify is %s, expected is %s", classify, spec.left)
+		}
+		if classify, _ := f.Classify(); classify != spec.classify {
+			t.Errorf("Classify is %s, expected is %s", classify, spec.classify)
+		}
+		if rs, err := f.Result(); err != nil || len(rs) != 2 {
+			t.Errorf("Should have a result, instead got %v", err)
+		}
+	}
+}
+
+func TestFlexibilityInvalid(t *testing.T) {
+	a, _ := NewAssessment("2015-May-22")
+
+	type flexibilityCase struct {
+		flexibility *Flexibility
+		err         string
+	}
+
+	cases := []flexibilityCase{
+		{NewSitAndReach(male, a), "Missing best"},
+		{NewBackSaverSitAndReach(male, a, map[int][]float64{LegRight: {25}, LegLeft: {25}}), "No classification for age"},
+		{NewBackSaverSitAndReach(male, a, map[int][]float64{LegRight: {25}}), "Missing left leg"},
+		{NewVSit(female, a, 10), "No classification for age"},
+	}
+
+	for _, data := range cases {
+		if _, err := data.flexibility.Classify(); err == nil || !strings.Contains(err.Error(), data.err) {
+			t.Errorf("Should show proper error message %s, got %v", data.err, err)
+		}
+		if _, err := data.flexibility.Result(); err == nil {
+			t.Error("Result should show an error")
+		}
+	}
+}
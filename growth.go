@@ -0,0 +1,672 @@
+package phass
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/**
+ * Constants
+ */
+
+// Growth indicator constants.
+const (
+	// GrowthWeightForAge: weight-for-age, in kg.
+	GrowthWeightForAge int = iota
+	// GrowthHeightForAge: length/height-for-age, in cm.
+	GrowthHeightForAge
+	// GrowthBMIForAge: BMI-for-age, in kg/m^2.
+	GrowthBMIForAge
+	// GrowthHeadCircumferenceForAge: head circumference-for-age, in cm.
+	GrowthHeadCircumferenceForAge
+)
+
+/**
+ * Growth references
+ */
+
+// Growth measurement for different references.
+var (
+	NewWHOGrowth = FactoryGrowth(WHOGrowthReference)
+	NewCDCGrowth = FactoryGrowth(CDCGrowthReference)
+)
+
+/**
+ * Growth
+ */
+
+// Growth represents a growth indicator measured for a child, compared
+// against a growth reference through the LMS method.
+type Growth struct {
+	*Person
+	*Assessment
+	Indicator int
+	Value     float64
+	*GrowthReference
+}
+
+// FactoryGrowth factory to create new growth measurements, for a given growth
+// reference. It returns a function to create new Growth structs.
+func FactoryGrowth(ref *GrowthReference) func(*Person, *Assessment, int, float64) *Growth {
+	return func(p *Person, a *Assessment, indicator int, value float64) *Growth {
+		return NewGrowth(p, a, indicator, value, ref)
+	}
+}
+
+// NewGrowth creates a new growth measurement. It receives a person, an
+// assessment, the indicator and its value, and the growth reference used.
+// Returns a pointer to Growth.
+func NewGrowth(p *Person, a *Assessment, indicator int, value float64, ref *GrowthReference) *Growth {
+	return &Growth{p, a, indicator, value, ref}
+}
+
+// NewBMIForAge creates a new BMI-for-age measurement, with BMI calculated from
+// anthropometric data, compared against the given growth reference. Returns
+// an error when BMI can't be calculated.
+func NewBMIForAge(p *Person, a *Assessment, an *Anthropometry, ref *GrowthReference) (*Growth, error) {
+	bmi, err := NewBMI(an.Weight, an.Height).Calc()
+	if err != nil {
+		return nil, err
+	}
+	return NewGrowth(p, a, GrowthBMIForAge, bmi, ref), nil
+}
+
+func (g *Growth) String() string {
+	v, _ := g.Calc()
+	c, _ := g.Classify()
+	return fmt.Sprintf("%s z-score: %.2f (%s)", NamedGrowthIndicator(g.Indicator), v, c)
+}
+
+// GetName returns this measurement name.
+func (g *Growth) GetName() string {
+	return "Growth"
+}
+
+// Result returns z-score, percentile and classification for this growth
+// indicator.
+func (g *Growth) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := g.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	c, err := g.Classify()
+	if err != nil {
+		return rs, err
+	}
+
+	p, _ := g.Percentile()
+	rs = append(
+		rs,
+		fmt.Sprintf("%s (%s): %.2f.", NamedGrowthIndicator(g.Indicator), g.GrowthReference.Name, g.Value),
+		fmt.Sprintf("%s z-score: %.2f.", NamedGrowthIndicator(g.Indicator), v),
+		fmt.Sprintf("%s percentile: %.1f.", NamedGrowthIndicator(g.Indicator), p),
+		fmt.Sprintf("%s classification: %s.", NamedGrowthIndicator(g.Indicator), c),
+	)
+	return rs, nil
+}
+
+// Percentile returns the percentile for this growth indicator.
+func (g *Growth) Percentile() (float64, error) {
+	z, err := g.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	return 50 * (1 + math.Erf(z/math.Sqrt2)), nil
+}
+
+// Classify returns the classification for this growth indicator, according
+// to the growth reference.
+func (g *Growth) Classify() (string, error) {
+	z, err := g.Calc()
+	if err != nil {
+		return "", err
+	}
+
+	age := g.Person.AgeInMonthsFromDate(g.Assessment.Date)
+	lms, err := g.GrowthReference.LMS(g.Indicator, g.Person.Gender, age)
+	if err != nil {
+		return "", err
+	}
+
+	return Classifier(z, g.GrowthReference.limits(g.Indicator, age, lms), GrowthClassification), nil
+}
+
+// Calc returns the z-score for this growth indicator.
+func (g *Growth) Calc() (float64, error) {
+	return g.equation().Calc()
+}
+
+// equation returns an equation, used to calculate the z-score.
+func (g *Growth) equation() Equationer {
+	conf := g.GrowthReference.conf(g.Indicator)
+	return NewEquation(conf.Extract(g), conf)
+}
+
+// NamedGrowthIndicator returns the name for a given growth indicator constant.
+func NamedGrowthIndicator(indicator int) string {
+	named := map[int]string{
+		GrowthWeightForAge:            "Weight-for-age",
+		GrowthHeightForAge:            "Height-for-age",
+		GrowthBMIForAge:               "BMI-for-age",
+		GrowthHeadCircumferenceForAge: "Head circumference-for-age",
+	}
+	return named[indicator]
+}
+
+/**
+ * Growth reference
+ */
+
+// GrowthReference represents a growth reference, as LMS parameters for each
+// indicator and gender by age in months, with its classification limits on
+// z-scores. The LMS parameters are loaded from the published tables, and
+// linearly interpolated between their rows.
+type GrowthReference struct {
+	Name string
+	// tables maps indicator and gender to rows of age in months, L, M and S.
+	tables map[int]map[int][][4]float64
+	// restricted indicators have z-scores beyond +/-3 adjusted, as
+	// recommended by WHO for weight based indicators.
+	restricted map[int]bool
+	// limits returns z-score classification limits for an indicator and age.
+	limits func(int, float64, [3]float64) map[int][2]float64
+}
+
+// LMS returns the L, M and S parameters for an indicator, gender and age in
+// months.
+func (r *GrowthReference) LMS(indicator, gender int, age float64) ([3]float64, error) {
+	rows, err := r.rows(indicator, gender)
+	if err != nil {
+		return [3]float64{}, err
+	}
+
+	if age < rows[0][0] || age > rows[len(rows)-1][0] {
+		return [3]float64{}, fmt.Errorf("Valid for age in months between %g and %g", rows[0][0], rows[len(rows)-1][0])
+	}
+
+	lms := [3]float64{}
+	for i := range lms {
+		table := make([][2]float64, len(rows))
+		for j, row := range rows {
+			table[j] = [2]float64{row[0], row[i+1]}
+		}
+		lms[i] = interpolate(age, table)
+	}
+	return lms, nil
+}
+
+// Load reads LMS parameters for an indicator and gender from a published
+// table, such as the WHO expanded tables, merging them into this reference.
+// The table must have a header row naming the age column (Month or Day) and
+// the L, M and S columns, with values separated by spaces, tabs or commas.
+// Tables with a Sex column, such as the CDC data files, have only rows for
+// the given gender loaded (1 for male, 2 for female). Other columns are
+// ignored, and rows for an age already loaded replace the
+// previous parameters. Load is not safe for concurrent use, and should be
+// called before measurements are made.
+func (r *GrowthReference) Load(indicator, gender int, rd io.Reader) error {
+	loaded := map[float64][4]float64{}
+	rows, _ := r.rows(indicator, gender)
+	for _, row := range rows {
+		loaded[row[0]] = row
+	}
+
+	var header []string
+	var columns map[string]int
+	scanner := bufio.NewScanner(rd)
+	for line := 1; scanner.Scan(); line++ {
+		fields := lmsFields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if columns == nil {
+			var err error
+			if columns, err = lmsColumns(fields); err != nil {
+				return err
+			}
+			header = fields
+			continue
+		}
+		if strings.EqualFold(strings.Join(fields, ","), strings.Join(header, ",")) {
+			continue
+		}
+
+		if ok, err := lmsSex(fields, columns, gender, line); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		row, err := lmsRow(fields, columns, line)
+		if err != nil {
+			return err
+		}
+		loaded[row[0]] = row
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(loaded) == 0 {
+		return fmt.Errorf("No LMS rows for %s %s", r.Name, NamedGrowthIndicator(indicator))
+	}
+
+	rows = make([][4]float64, 0, len(loaded))
+	for _, row := range loaded {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+
+	if _, ok := r.tables[indicator]; !ok {
+		r.tables[indicator] = map[int][][4]float64{}
+	}
+	r.tables[indicator][gender] = rows
+	return nil
+}
+
+// loadEmbedded loads LMS tables embedded with this package. Embedded tables
+// are verified by tests, hence an error loading them is a programming error.
+func (r *GrowthReference) loadEmbedded(tables []growthTable) {
+	for _, t := range tables {
+		f, err := growthData.Open(t.file)
+		if err != nil {
+			panic(err)
+		}
+		err = r.Load(t.indicator, t.gender, f)
+		f.Close()
+		if err != nil {
+			panic(fmt.Sprintf("%s: %s", t.file, err))
+		}
+	}
+}
+
+// rows returns the LMS table for an indicator and gender.
+func (r *GrowthReference) rows(indicator, gender int) ([][4]float64, error) {
+	byGender, ok := r.tables[indicator]
+	if !ok {
+		return nil, fmt.Errorf("No %s reference for %s", r.Name, NamedGrowthIndicator(indicator))
+	}
+	rows, ok := byGender[gender]
+	if !ok {
+		return nil, fmt.Errorf("No %s reference for gender %d", r.Name, gender)
+	}
+	return rows, nil
+}
+
+// conf returns an equation configuration to calculate z-score for a given
+// indicator.
+func (r *GrowthReference) conf(indicator int) *EquationConf {
+	return NewEquationConf(
+		fmt.Sprintf("%s %s", r.Name, NamedGrowthIndicator(indicator)),
+		func(i interface{}) InParams {
+			g := i.(*Growth)
+			return map[string]float64{
+				"age in months": g.Person.AgeInMonthsFromDate(g.Assessment.Date),
+				"gender":        float64(g.Person.Gender),
+				"value":         g.Value,
+			}
+		},
+		[]Validator{
+			ValidateMeasures([]string{"age in months", "gender", "value"}),
+			func(e *Equation) (bool, error) {
+				if v, _ := e.In("value"); v <= 0 {
+					return false, fmt.Errorf("Value must be greater than zero")
+				}
+				age, _ := e.In("age in months")
+				gender, _ := e.In("gender")
+				if _, err := r.LMS(indicator, int(gender), age); err != nil {
+					return false, err
+				}
+				return true, nil
+			},
+		},
+		func(e *Equation) float64 {
+			age, _ := e.In("age in months")
+			gender, _ := e.In("gender")
+			v, _ := e.In("value")
+			lms, _ := r.LMS(indicator, int(gender), age)
+			if r.restricted[indicator] {
+				return lmsRestrictedZScore(v, lms)
+			}
+			return lmsZScore(v, lms)
+		},
+	)
+}
+
+/**
+ * LMS method
+ */
+
+// lmsFields splits a line of a published LMS table into its fields.
+func lmsFields(line string) []string {
+	fields := strings.FieldsFunc(line, func(c rune) bool {
+		return c == ',' || unicode.IsSpace(c)
+	})
+	for i, f := range fields {
+		fields[i] = strings.Trim(f, `"`)
+	}
+	return fields
+}
+
+// lmsColumns returns the position of age, L, M and S columns in the header
+// of a published LMS table, and if age is expressed in days.
+func lmsColumns(header []string) (map[string]int, error) {
+	columns := map[string]int{}
+	for i, f := range header {
+		switch strings.ToLower(f) {
+		case "month", "agemos":
+			columns["age"] = i
+		case "day":
+			columns["age"] = i
+			columns["days"] = 1
+		case "sex":
+			columns["sex"] = i
+		case "l", "m", "s":
+			columns[strings.ToUpper(f)] = i
+		}
+	}
+	for _, k := range []string{"age", "L", "M", "S"} {
+		if _, ok := columns[k]; !ok {
+			return nil, fmt.Errorf("Missing %s column", k)
+		}
+	}
+	return columns, nil
+}
+
+// lmsSex returns if a row of a published LMS table is for the given gender.
+// Tables without a Sex column have all rows for the gender loaded.
+func lmsSex(fields []string, columns map[string]int, gender, line int) (bool, error) {
+	idx, ok := columns["sex"]
+	if !ok {
+		return true, nil
+	}
+	if idx >= len(fields) {
+		return false, fmt.Errorf("Missing sex value in line %d", line)
+	}
+	v, err := strconv.Atoi(fields[idx])
+	if err != nil {
+		return false, fmt.Errorf("Invalid sex value %q in line %d", fields[idx], line)
+	}
+	return v == lmsSexCodes[gender], nil
+}
+
+// lmsSexCodes maps gender constants to the codes used in published tables.
+var lmsSexCodes = map[int]int{
+	Male:   1,
+	Female: 2,
+}
+
+// lmsRow returns age in months, L, M and S from a row of a published LMS
+// table.
+func lmsRow(fields []string, columns map[string]int, line int) ([4]float64, error) {
+	row := [4]float64{}
+	for i, k := range []string{"age", "L", "M", "S"} {
+		idx := columns[k]
+		if idx >= len(fields) {
+			return row, fmt.Errorf("Missing %s value in line %d", k, line)
+		}
+		v, err := strconv.ParseFloat(fields[idx], 64)
+		if err != nil {
+			return row, fmt.Errorf("Invalid %s value %q in line %d", k, fields[idx], line)
+		}
+		row[i] = v
+	}
+	if columns["days"] == 1 {
+		row[0] /= daysInMonth
+	}
+	return row, nil
+}
+
+// lmsZScore returns the z-score for a value, given its L, M and S parameters.
+func lmsZScore(x float64, lms [3]float64) float64 {
+	l, m, s := lms[0], lms[1], lms[2]
+	if l == 0 {
+		return math.Log(x/m) / s
+	}
+	return (math.Pow(x/m, l) - 1) / (l * s)
+}
+
+// lmsValue returns the value for a z-score, given its L, M and S parameters.
+func lmsValue(z float64, lms [3]float64) float64 {
+	l, m, s := lms[0], lms[1], lms[2]
+	if l == 0 {
+		return m * math.Exp(s*z)
+	}
+	return m * math.Pow(1+l*s*z, 1/l)
+}
+
+// lmsRestrictedZScore returns the z-score for a value, with z-scores beyond
+// +/-3 computed from the distance between the 2 and 3 SD values.
+func lmsRestrictedZScore(x float64, lms [3]float64) float64 {
+	z := lmsZScore(x, lms)
+	switch {
+	case z > 3:
+		sd3 := lmsValue(3, lms)
+		return 3 + (x-sd3)/(sd3-lmsValue(2, lms))
+	case z < -3:
+		sd3 := lmsValue(-3, lms)
+		return -3 + (x-sd3)/(lmsValue(-2, lms)-sd3)
+	}
+	return z
+}
+
+/**
+ * Classification
+ */
+
+// Growth classification constants.
+const (
+	GrowthNormal = iota
+	GrowthSeverelyUnderweight
+	GrowthUnderweight
+	GrowthSeverelyStunted
+	GrowthStunted
+	GrowthSeverelyWasted
+	GrowthWasted
+	GrowthSevereThinness
+	GrowthThinness
+	GrowthRiskOfOverweight
+	GrowthOverweight
+	GrowthObese
+	GrowthSeverelyObese
+	GrowthShortStature
+	GrowthMicrocephaly
+	GrowthMacrocephaly
+)
+
+// GrowthClassification map growth classification constants to their string
+// representation.
+var GrowthClassification = map[int]string{
+	GrowthNormal:              "Normal",
+	GrowthSeverelyUnderweight: "Severely underweight",
+	GrowthUnderweight:         "Underweight",
+	GrowthSeverelyStunted:     "Severely stunted",
+	GrowthStunted:             "Stunted",
+	GrowthSeverelyWasted:      "Severely wasted",
+	GrowthWasted:              "Wasted",
+	GrowthSevereThinness:      "Severe thinness",
+	GrowthThinness:            "Thinness",
+	GrowthRiskOfOverweight:    "Possible risk of overweight",
+	GrowthOverweight:          "Overweight",
+	GrowthObese:               "Obese",
+	GrowthSeverelyObese:       "Severely obese",
+	GrowthShortStature:        "Short stature",
+	GrowthMicrocephaly:        "Microcephaly",
+	GrowthMacrocephaly:        "Macrocephaly",
+}
+
+// whoGrowthLimits returns WHO z-score classification limits for a given
+// indicator and age in months.
+func whoGrowthLimits(indicator int, age float64, lms [3]float64) map[int][2]float64 {
+	inf := math.Inf(+1)
+	switch indicator {
+	case GrowthWeightForAge:
+		return map[int][2]float64{
+			GrowthSeverelyUnderweight: {-inf, -3},
+			GrowthUnderweight:         {-3, -2},
+			GrowthNormal:              {-2, inf},
+		}
+	case GrowthHeightForAge:
+		return map[int][2]float64{
+			GrowthSeverelyStunted: {-inf, -3},
+			GrowthStunted:         {-3, -2},
+			GrowthNormal:          {-2, inf},
+		}
+	case GrowthHeadCircumferenceForAge:
+		return map[int][2]float64{
+			GrowthMicrocephaly: {-inf, -2},
+			GrowthNormal:       {-2, math.Nextafter(2, inf)},
+			GrowthMacrocephaly: {math.Nextafter(2, inf), inf},
+		}
+	}
+
+	if age < 60 {
+		return map[int][2]float64{
+			GrowthSeverelyWasted:   {-inf, -3},
+			GrowthWasted:           {-3, -2},
+			GrowthNormal:           {-2, math.Nextafter(1, inf)},
+			GrowthRiskOfOverweight: {math.Nextafter(1, inf), math.Nextafter(2, inf)},
+			GrowthOverweight:       {math.Nextafter(2, inf), math.Nextafter(3, inf)},
+			GrowthObese:            {math.Nextafter(3, inf), inf},
+		}
+	}
+	return map[int][2]float64{
+		GrowthSevereThinness: {-inf, -3},
+		GrowthThinness:       {-3, -2},
+		GrowthNormal:         {-2, math.Nextafter(1, inf)},
+		GrowthOverweight:     {math.Nextafter(1, inf), math.Nextafter(2, inf)},
+		GrowthObese:          {math.Nextafter(2, inf), inf},
+	}
+}
+
+// cdcGrowthLimits returns CDC classification limits, on z-scores, for a given
+// indicator, based in the 5th, 85th and 95th percentiles. Severe obesity is
+// defined as a BMI of 120% of the 95th percentile or above.
+func cdcGrowthLimits(indicator int, age float64, lms [3]float64) map[int][2]float64 {
+	inf := math.Inf(+1)
+	p5, p85, p95 := -1.6449, 1.0364, 1.6449
+	switch indicator {
+	case GrowthWeightForAge:
+		return map[int][2]float64{
+			GrowthUnderweight: {-inf, p5},
+			GrowthNormal:      {p5, inf},
+		}
+	case GrowthHeightForAge:
+		return map[int][2]float64{
+			GrowthShortStature: {-inf, p5},
+			GrowthNormal:       {p5, inf},
+		}
+	case GrowthHeadCircumferenceForAge:
+		return map[int][2]float64{
+			GrowthMicrocephaly: {-inf, -2},
+			GrowthNormal:       {-2, math.Nextafter(2, inf)},
+			GrowthMacrocephaly: {math.Nextafter(2, inf), inf},
+		}
+	}
+
+	severe := lmsZScore(1.2*lmsValue(p95, lms), lms)
+	return map[int][2]float64{
+		GrowthUnderweight:   {-inf, p5},
+		GrowthNormal:        {p5, p85},
+		GrowthOverweight:    {p85, p95},
+		GrowthObese:         {p95, severe},
+		GrowthSeverelyObese: {severe, inf},
+	}
+}
+
+/**
+ * Embedded LMS tables
+ */
+
+// growthData holds the LMS tables embedded with this package, in the format
+// of the published tables. Tables not covering the full age range can be
+// completed with Load.
+//
+//go:embed growth
+var growthData embed.FS
+
+// growthTable represents an embedded LMS table file, for an indicator and
+// gender.
+type growthTable struct {
+	file      string
+	indicator int
+	gender    int
+}
+
+/**
+ * WHO growth reference
+ */
+
+// WHOGrowthReference represents the WHO Child Growth Standards (2006), from
+// birth to 5 years, and the WHO Growth Reference (2007), from 5 to 19 years,
+// with the embedded LMS tables parsed once, when the package is initialized.
+var WHOGrowthReference = NewWHOGrowthReference()
+
+// NewWHOGrowthReference returns a WHO growth reference, with the embedded LMS
+// tables, WHO classification limits and z-scores beyond +/-3 adjusted for
+// weight based indicators.
+func NewWHOGrowthReference() *GrowthReference {
+	r := &GrowthReference{
+		Name:   "WHO",
+		tables: map[int]map[int][][4]float64{},
+		restricted: map[int]bool{
+			GrowthWeightForAge: true,
+			GrowthBMIForAge:    true,
+		},
+		limits: whoGrowthLimits,
+	}
+	r.loadEmbedded(whoGrowthTables)
+	return r
+}
+
+// whoGrowthTables represents the WHO z-score tables embedded, by month of
+// age.
+var whoGrowthTables = []growthTable{
+	{"growth/who/weight-for-age-boys.txt", GrowthWeightForAge, Male},
+	{"growth/who/weight-for-age-girls.txt", GrowthWeightForAge, Female},
+	{"growth/who/length-height-for-age-boys.txt", GrowthHeightForAge, Male},
+	{"growth/who/length-height-for-age-girls.txt", GrowthHeightForAge, Female},
+	{"growth/who/bmi-for-age-boys.txt", GrowthBMIForAge, Male},
+	{"growth/who/bmi-for-age-girls.txt", GrowthBMIForAge, Female},
+	{"growth/who/head-circumference-for-age-boys.txt", GrowthHeadCircumferenceForAge, Male},
+	{"growth/who/head-circumference-for-age-girls.txt", GrowthHeadCircumferenceForAge, Female},
+}
+
+/**
+ * CDC growth reference
+ */
+
+// CDCGrowthReference represents the CDC 2000 growth charts, from 2 to 20
+// years, with head circumference from the infant charts, from birth to 36
+// months, with the embedded LMS tables parsed once, when the package is
+// initialized.
+var CDCGrowthReference = NewCDCGrowthReference()
+
+// NewCDCGrowthReference returns a CDC growth reference, with the embedded LMS
+// tables and CDC percentile classification limits.
+func NewCDCGrowthReference() *GrowthReference {
+	r := &GrowthReference{
+		Name:       "CDC",
+		tables:     map[int]map[int][][4]float64{},
+		restricted: map[int]bool{},
+		limits:     cdcGrowthLimits,
+	}
+	r.loadEmbedded(cdcGrowthTables)
+	return r
+}
+
+// cdcGrowthTables represents the CDC data files embedded, with rows for both
+// sexes, by month of age.
+var cdcGrowthTables = []growthTable{
+	{"growth/cdc/bmi-for-age.csv", GrowthBMIForAge, Male},
+	{"growth/cdc/bmi-for-age.csv", GrowthBMIForAge, Female},
+}
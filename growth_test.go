@@ -0,0 +1,243 @@
+package phass
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWHOGrowthReference(t *testing.T) {
+	for _, table := range whoGrowthTables {
+		data, err := growthData.ReadFile(table.file)
+		if err != nil {
+			t.Fatalf("Unexpected error reading %s: %s", table.file, err)
+		}
+		checkPublished(t, WHOGrowthReference, table.indicator, table.gender, string(data), []float64{-3, -2, -1, 0, 1, 2, 3})
+	}
+}
+
+func TestWHOGrowth(t *testing.T) {
+	birth, _ := NewAssessment("1978-Dec-15")
+
+	cases := []struct {
+		indicator int
+		value     float64
+		zlower    float64
+		zupper    float64
+		class     int
+	}{
+		{GrowthWeightForAge, 3.3, -0.1, 0.1, GrowthNormal},
+		{GrowthWeightForAge, 2.4, -3, -2, GrowthUnderweight},
+		{GrowthWeightForAge, 2.0, -4, -3, GrowthSeverelyUnderweight},
+		{GrowthHeightForAge, 49.9, -0.1, 0.1, GrowthNormal},
+		{GrowthHeightForAge, 45.0, -3, -2, GrowthStunted},
+		{GrowthBMIForAge, 17.0, 2, 3, GrowthOverweight},
+		{GrowthBMIForAge, 19.0, 3, 4, GrowthObese},
+		{GrowthHeadCircumferenceForAge, 37.5, 2, 3, GrowthMacrocephaly},
+		{GrowthHeadCircumferenceForAge, 31.0, -3, -2, GrowthMicrocephaly},
+	}
+
+	for _, c := range cases {
+		g := NewWHOGrowth(male, birth, c.indicator, c.value)
+		z, err := g.Calc()
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", NamedGrowthIndicator(c.indicator), err)
+		}
+		if z < c.zlower || z > c.zupper {
+			t.Errorf("%s z-score expected between %.1f and %.1f, got %.2f", NamedGrowthIndicator(c.indicator), c.zlower, c.zupper, z)
+		}
+
+		p, _ := g.Percentile()
+		if e := 50 * (1 + math.Erf(z/math.Sqrt2)); !floatEqual(p, e, 0.0001) {
+			t.Errorf("%s percentile expected %.2f, got %.2f", NamedGrowthIndicator(c.indicator), e, p)
+		}
+
+		class, _ := g.Classify()
+		if class != GrowthClassification[c.class] {
+			t.Errorf("%s classification expected %s, got %s", NamedGrowthIndicator(c.indicator), GrowthClassification[c.class], class)
+		}
+
+		if _, err := g.Result(); err != nil {
+			t.Errorf("Unexpected result error for %s: %s", NamedGrowthIndicator(c.indicator), err)
+		}
+	}
+}
+
+func TestWHOGrowthErrors(t *testing.T) {
+	birth, _ := NewAssessment("1978-Dec-15")
+	adult, _ := NewAssessment("2016-Jan-01")
+
+	cases := []struct {
+		ref        *GrowthReference
+		assessment *Assessment
+		indicator  int
+		value      float64
+		err        string
+	}{
+		{WHOGrowthReference, adult, GrowthHeightForAge, 178, "Valid for age in months between 0 and"},
+		{WHOGrowthReference, birth, GrowthWeightForAge, 0, "Value must be greater than zero"},
+		{WHOGrowthReference, birth, 10, 10, "No WHO reference"},
+		{newEmptyGrowthReference("WHO"), birth, GrowthWeightForAge, 3.3, "No WHO reference for Weight-for-age"},
+	}
+
+	for _, c := range cases {
+		_, err := NewGrowth(male, c.assessment, c.indicator, c.value, c.ref).Calc()
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("Expected error containing %q, got %v", c.err, err)
+		}
+	}
+}
+
+func TestGrowthReferenceLoadErrors(t *testing.T) {
+	cases := []struct {
+		table string
+		err   string
+	}{
+		{"Month L M\n0 1 49.8842", "Missing S column"},
+		{"Month L M S\n0 1 49.8842 abc", "Invalid S value \"abc\" in line 2"},
+		{"Month L M S\n0 1 49.8842", "Missing S value in line 2"},
+		{"Month L M S\n", "No LMS rows"},
+	}
+
+	for _, c := range cases {
+		err := newEmptyGrowthReference("WHO").Load(GrowthHeightForAge, Male, strings.NewReader(c.table))
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("Expected error containing %q, got %v", c.err, err)
+		}
+	}
+
+	// rows loaded by days are converted to months, and merged with rows
+	// already loaded.
+	ref := newEmptyGrowthReference("WHO")
+	ref.Load(GrowthHeightForAge, Male, strings.NewReader("Month L M S\n0 1 49.8842 0.03795"))
+	ref.Load(GrowthHeightForAge, Male, strings.NewReader("Day,L,M,S\n61,1,58.4249,0.03424"))
+	lms, err := ref.LMS(GrowthHeightForAge, Male, 61/daysInMonth)
+	if err != nil || !floatEqual(lms[1], 58.4249, 0.0001) {
+		t.Errorf("Expected median 58.4249, got %.4f (%v)", lms[1], err)
+	}
+}
+
+func TestLMSRestrictedZScore(t *testing.T) {
+	lms := [3]float64{-0.3, 16, 0.1}
+	for _, z := range []float64{-2.5, 0, 2.5} {
+		if v := lmsRestrictedZScore(lmsValue(z, lms), lms); !floatEqual(v, z, 0.0001) {
+			t.Errorf("Expected z-score %.2f, got %.4f", z, v)
+		}
+	}
+
+	sd2, sd3 := lmsValue(2, lms), lmsValue(3, lms)
+	if v := lmsRestrictedZScore(sd3+(sd3-sd2), lms); !floatEqual(v, 4, 0.0001) {
+		t.Errorf("Expected restricted z-score 4, got %.4f", v)
+	}
+}
+
+func TestCDCGrowthReference(t *testing.T) {
+	for _, table := range cdcGrowthTables {
+		data, err := growthData.ReadFile(table.file)
+		if err != nil {
+			t.Fatalf("Unexpected error reading %s: %s", table.file, err)
+		}
+		checkPublished(t, CDCGrowthReference, table.indicator, table.gender, string(data), []float64{-1.6449, 0, 1.6449})
+	}
+
+	ref := newEmptyGrowthReference("CDC")
+	ref.Load(GrowthBMIForAge, Female, strings.NewReader("Sex,Agemos,L,M,S\n1,24,-2.01118107,16.57502768,0.080592465\n1,24.5,-1.982373595,16.54777487,0.080127429\n2,24,-0.98660853,16.42339664,0.085451785"))
+	if _, err := ref.LMS(GrowthBMIForAge, Female, 24.5); err == nil || !strings.Contains(err.Error(), "Valid for age in months between 24 and 24") {
+		t.Errorf("Expected only female rows loaded, got %v", err)
+	}
+	if err := ref.Load(GrowthBMIForAge, Male, strings.NewReader("Sex,Agemos,L,M,S\nM,24,1,16,0.08")); err == nil || !strings.Contains(err.Error(), "Invalid sex value") {
+		t.Errorf("Expected sex error, got %v", err)
+	}
+}
+
+func TestCDCGrowth(t *testing.T) {
+	toddler, _ := NewAssessment("1980-Dec-15")
+
+	cases := []struct {
+		value  float64
+		zlower float64
+		zupper float64
+		class  int
+	}{
+		{16.6, -0.1, 0.1, GrowthNormal},
+		{14.0, -3, -1.6449, GrowthUnderweight},
+		{18.8, 1.0364, 1.6449, GrowthOverweight},
+		{21.0, 1.6449, 3, GrowthObese},
+		{24.0, 2, 4, GrowthSeverelyObese},
+	}
+
+	for _, c := range cases {
+		g := NewCDCGrowth(male, toddler, GrowthBMIForAge, c.value)
+		z, err := g.Calc()
+		if err != nil {
+			t.Fatalf("Unexpected error for BMI %.1f: %s", c.value, err)
+		}
+		if z < c.zlower || z > c.zupper {
+			t.Errorf("BMI %.1f z-score expected between %.2f and %.2f, got %.2f", c.value, c.zlower, c.zupper, z)
+		}
+
+		class, _ := g.Classify()
+		if class != GrowthClassification[c.class] {
+			t.Errorf("BMI %.1f classification expected %s, got %s", c.value, GrowthClassification[c.class], class)
+		}
+	}
+
+	infant, _ := NewAssessment("1979-Dec-15")
+	if _, err := NewCDCGrowth(male, infant, GrowthBMIForAge, 17).Calc(); err == nil || !strings.Contains(err.Error(), "Valid for age in months between 24 and") {
+		t.Errorf("Expected age error, got %v", err)
+	}
+}
+
+func TestBMIForAge(t *testing.T) {
+	toddler, _ := NewAssessment("1980-Dec-15")
+
+	g, err := NewBMIForAge(male, toddler, NewAnthropometry(12.2, 86), CDCGrowthReference)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !floatEqual(g.Value, 16.50, 0.01) {
+		t.Errorf("BMI expected 16.50, got %.2f", g.Value)
+	}
+	if c, _ := g.Classify(); c != GrowthClassification[GrowthNormal] {
+		t.Errorf("Classification expected Normal, got %s", c)
+	}
+
+	if _, err := NewBMIForAge(male, toddler, NewAnthropometry(0, 86), CDCGrowthReference); err == nil {
+		t.Error("Expected error for missing weight")
+	}
+}
+
+/**
+ * Common data for testing
+ */
+
+// newEmptyGrowthReference returns a growth reference without LMS tables.
+func newEmptyGrowthReference(name string) *GrowthReference {
+	return &GrowthReference{Name: name, tables: map[int]map[int][][4]float64{}}
+}
+
+// checkPublished ensures values for each z-score calculated from the loaded
+// LMS parameters match the values published with them, in the columns after
+// the S column.
+func checkPublished(t *testing.T, ref *GrowthReference, indicator, gender int, table string, zs []float64) {
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+	columns, _ := lmsColumns(lmsFields(lines[0]))
+	for i, line := range lines[1:] {
+		fields := lmsFields(line)
+		if ok, _ := lmsSex(fields, columns, gender, i+2); !ok {
+			continue
+		}
+		age, _ := strconv.ParseFloat(fields[columns["age"]], 64)
+		lms, err := ref.LMS(indicator, gender, age)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s at %g months: %s", NamedGrowthIndicator(indicator), age, err)
+		}
+		for j, z := range zs {
+			expect, _ := strconv.ParseFloat(fields[columns["S"]+1+j], 64)
+			if v := lmsValue(z, lms); math.Abs(v-expect) > 0.051 {
+				t.Errorf("%s at %g months for z-score %.2f expected %.1f, got %.2f", NamedGrowthIndicator(indicator), age, z, expect, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Constants
+ */
+
+// Hand constants.
+const (
+	// HandRight: right hand.
+	HandRight int = iota
+	// HandLeft: left hand.
+	HandLeft
+)
+
+// Trial selection constants.
+const (
+	// TrialBest: select the best trial.
+	TrialBest int = iota
+	// TrialMean: select the mean of all trials.
+	TrialMean
+)
+
+/**
+ * Handgrip
+ */
+
+// Handgrip represents the handgrip strength, measured with a dynamometer in
+// both hands with multiple trials (in kg), for a person in an assessment.
+type Handgrip struct {
+	*Person
+	*Assessment
+	Trials    map[int][]float64
+	Dominant  int
+	Selection int
+}
+
+// NewHandgrip creates a new handgrip measurement, based in person, assessment,
+// the dominant hand, how trials are selected, and the trials for each hand.
+func NewHandgrip(person *Person, assessment *Assessment, dominant, selection int, trials map[int][]float64) *Handgrip {
+	return &Handgrip{person, assessment, trials, dominant, selection}
+}
+
+func (h *Handgrip) String() string {
+	v, _ := h.Calc()
+	c, _ := h.Classify()
+	return fmt.Sprintf("Combined handgrip: %.1f kg (%s)", v, c)
+}
+
+// GetName returns this measurement name.
+func (h *Handgrip) GetName() string {
+	return "Handgrip"
+}
+
+// Result returns relevant information about handgrip assessment.
+func (h *Handgrip) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := h.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	c, err := h.Classify()
+	if err != nil {
+		return rs, err
+	}
+
+	risk, err := h.Sarcopenia()
+	if err != nil {
+		return rs, err
+	}
+
+	right, _ := h.Hand(HandRight)
+	left, _ := h.Hand(HandLeft)
+	dominant, _ := h.Hand(h.Dominant)
+	rs = append(
+		rs,
+		fmt.Sprintf("Handgrip right hand: %.1f kg.", right),
+		fmt.Sprintf("Handgrip left hand: %.1f kg.", left),
+		fmt.Sprintf("Handgrip dominant hand (%s): %.1f kg.", NamedHand(h.Dominant), dominant),
+		fmt.Sprintf("Combined handgrip: %.1f kg.", v),
+		fmt.Sprintf("Handgrip classification: %s.", c),
+		fmt.Sprintf("Sarcopenia risk (EWGSOP2): %t.", risk),
+	)
+	return rs, nil
+}
+
+// Hand returns the selected trial value for a given hand.
+func (h *Handgrip) Hand(hand int) (float64, error) {
+	trials := h.Trials[hand]
+	if len(trials) == 0 {
+		return 0.0, fmt.Errorf("Missing %s trials", NamedHand(hand))
+	}
+
+	switch h.Selection {
+	case TrialBest:
+		best := math.Inf(-1)
+		for _, v := range trials {
+			best = math.Max(best, v)
+		}
+		return best, nil
+	case TrialMean:
+		accum := 0.0
+		for _, v := range trials {
+			accum += v
+		}
+		return accum / float64(len(trials)), nil
+	}
+	return 0.0, fmt.Errorf("Unknown trial selection %d", h.Selection)
+}
+
+// Max returns the highest selected value between both hands.
+func (h *Handgrip) Max() (float64, error) {
+	right, rerr := h.Hand(HandRight)
+	left, lerr := h.Hand(HandLeft)
+	if rerr != nil && lerr != nil {
+		return 0.0, rerr
+	}
+	return math.Max(right, left), nil
+}
+
+// Sarcopenia returns if the handgrip strength is below the EWGSOP2 cut-off
+// points for probable sarcopenia.
+func (h *Handgrip) Sarcopenia() (bool, error) {
+	v, err := h.Max()
+	if err != nil {
+		return false, err
+	}
+	cutoff, ok := sarcopeniaGripCutoff[h.Person.Gender]
+	if !ok {
+		return false, fmt.Errorf("No cut-off for gender %d", h.Person.Gender)
+	}
+	return v < cutoff, nil
+}
+
+// Classify returns the classification for the combined handgrip strength.
+func (h *Handgrip) Classify() (string, error) {
+	v, err := h.Calc()
+	if err != nil {
+		return "", err
+	}
+
+	classes, err := limitsForGenderAndAge(handgripLimits, h.Person.Gender, h.Person.AgeFromDate(h.Assessment.Date))
+	if err != nil {
+		return "", err
+	}
+
+	return Classifier(v, classes, FitnessClassification), nil
+}
+
+// Calc returns the combined handgrip strength, the sum of both hands.
+func (h *Handgrip) Calc() (float64, error) {
+	return h.equation().Calc()
+}
+
+// equation returns an equation, used to calculate combined handgrip.
+func (h *Handgrip) equation() Equationer {
+	return NewEquation(handgripConf.Extract(h), handgripConf)
+}
+
+// NamedHand returns the name for a given hand constant.
+func NamedHand(hand int) string {
+	named := map[int]string{
+		HandRight: "right hand",
+		HandLeft:  "left hand",
+	}
+	return named[hand]
+}
+
+/**
+ * Equation
+ */
+
+var handgripConf = NewEquationConf(
+	"Combined handgrip",
+	func(i interface{}) InParams {
+		h := i.(*Handgrip)
+		r := map[string]float64{
+			"age":       h.Person.AgeFromDate(h.Assessment.Date),
+			"gender":    float64(h.Person.Gender),
+			"selection": float64(h.Selection),
+		}
+		for _, hand := range []int{HandRight, HandLeft} {
+			if v, err := h.Hand(hand); err == nil {
+				r[NamedHand(hand)] = v
+			}
+		}
+		return r
+	},
+	[]Validator{
+		func(e *Equation) (bool, error) {
+			v, _ := e.In("selection")
+			if s := int(v); s != TrialBest && s != TrialMean {
+				return false, fmt.Errorf("Unknown trial selection %d", s)
+			}
+			return true, nil
+		},
+		ValidateMeasures([]string{"age", "gender", NamedHand(HandRight), NamedHand(HandLeft)}),
+	},
+	func(e *Equation) float64 {
+		right, _ := e.In(NamedHand(HandRight))
+		left, _ := e.In(NamedHand(HandLeft))
+		return right + left
+	},
+)
+
+/**
+ * Classification
+ */
+
+// sarcopeniaGripCutoff represents EWGSOP2 handgrip cut-off points, in kg.
+var sarcopeniaGripCutoff = map[int]float64{
+	Male:   27,
+	Female: 16,
+}
+
+// handgripLimits represent the classification limits for combined handgrip
+// strength, for any given gender and age, from the Canadian Physical
+// Activity, Fitness and Lifestyle Approach.
+var handgripLimits = map[int]map[[2]float64]map[int][2]float64{
+	Male: {
+		{15, 20}: newFitnessLimits(79, 90, 98, 108),
+		{20, 30}: newFitnessLimits(84, 95, 104, 115),
+		{30, 40}: newFitnessLimits(84, 95, 104, 115),
+		{40, 50}: newFitnessLimits(80, 88, 97, 108),
+		{50, 60}: newFitnessLimits(76, 84, 92, 101),
+		{60, 70}: newFitnessLimits(73, 84, 91, 100),
+	},
+	Female: {
+		{15, 20}: newFitnessLimits(48, 53, 60, 68),
+		{20, 30}: newFitnessLimits(52, 58, 63, 70),
+		{30, 40}: newFitnessLimits(51, 58, 63, 71),
+		{40, 50}: newFitnessLimits(49, 54, 61, 69),
+		{50, 60}: newFitnessLimits(45, 49, 54, 61),
+		{60, 70}: newFitnessLimits(41, 45, 48, 54),
+	},
+}
@@ -0,0 +1,79 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHandgripCalcAndClassification(t *testing.T) {
+	type handgripSpec struct {
+		person     *Person
+		assessment string
+		selection  int
+		trials     map[int][]float64
+		calc       float64
+		classify   string
+		sarcopenia bool
+	}
+
+	specs := []handgripSpec{
+		{male, "2015-May-22", TrialBest, map[int][]float64{HandRight: {50, 52, 51}, HandLeft: {46, 48}}, 100, FitnessClassification[FitnessGood], false},
+		{male, "2015-May-22", TrialMean, map[int][]float64{HandRight: {50, 52, 51}, HandLeft: {46, 48}}, 98, FitnessClassification[FitnessGood], false},
+		{male, "2015-May-22", TrialBest, map[int][]float64{HandRight: {58, 60}, HandLeft: {55, 56}}, 116, FitnessClassification[FitnessExcellent], false},
+		{male, "2015-May-22", TrialBest, map[int][]float64{HandRight: {25}, HandLeft: {26}}, 51, FitnessClassification[FitnessNeedsImprovement], true},
+		{male, "2038-May-22", TrialBest, map[int][]float64{HandRight: {41}, HandLeft: {39}}, 80, FitnessClassification[FitnessFair], false},
+		{female, "2015-May-22", TrialBest, map[int][]float64{HandRight: {20, 22}, HandLeft: {18, 19}}, 41, FitnessClassification[FitnessNeedsImprovement], false},
+		{female, "2015-May-22", TrialBest, map[int][]float64{HandRight: {33, 35}, HandLeft: {30, 31}}, 66, FitnessClassification[FitnessVeryGood], false},
+		{female, "2015-May-22", TrialMean, map[int][]float64{HandRight: {14, 15}, HandLeft: {13}}, 27.5, FitnessClassification[FitnessNeedsImprovement], true},
+	}
+
+	for _, spec := range specs {
+		a, _ := NewAssessment(spec.assessment)
+		h := NewHandgrip(spec.person, a, HandRight, spec.selection, spec.trials)
+
+		if calc, err := h.Calc(); err != nil {
+			t.Errorf("Should not get an error: %s", err)
+		} else if !floatEqual(calc, spec.calc, 0.001) {
+			t.Errorf("Calc is %.2f, expected is %.2f", calc, spec.calc)
+		}
+		if classify, _ := h.Classify(); classify != spec.classify {
+			t.Errorf("Classify is %s, expected is %s", classify, spec.classify)
+		}
+		if risk, _ := h.Sarcopenia(); risk != spec.sarcopenia {
+			t.Errorf("Sarcopenia risk is %t, expected is %t", risk, spec.sarcopenia)
+		}
+		if rs, err := h.Result(); err != nil || len(rs) != 6 {
+			t.Errorf("Should have a result, instead got %v", err)
+		}
+	}
+}
+
+func TestHandgripInvalid(t *testing.T) {
+	a, _ := NewAssessment("2015-May-22")
+	young, _ := NewAssessment("1990-May-22")
+
+	type handgripCase struct {
+		handgrip *Handgrip
+		err      string
+	}
+
+	cases := []handgripCase{
+		{NewHandgrip(male, a, HandRight, TrialBest, map[int][]float64{HandRight: {50}}), "Missing left hand"},
+		{NewHandgrip(male, a, HandRight, 42, map[int][]float64{HandRight: {50}, HandLeft: {48}}), "Unknown trial selection 42"},
+		{NewHandgrip(male, young, HandRight, TrialBest, map[int][]float64{HandRight: {20}, HandLeft: {18}}), "No classification for age"},
+	}
+
+	for _, data := range cases {
+		if _, err := data.handgrip.Classify(); err == nil || !strings.Contains(err.Error(), data.err) {
+			t.Errorf("Should show proper error message %s, got %v", data.err, err)
+		}
+		if _, err := data.handgrip.Result(); err == nil {
+			t.Error("Result should show an error")
+		}
+	}
+
+	h := NewHandgrip(male, a, HandRight, TrialBest, map[int][]float64{HandRight: {50}})
+	if risk, err := h.Sarcopenia(); err != nil || risk {
+		t.Errorf("Sarcopenia should be evaluated with a single hand, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package phass
+
+import (
+	"fmt"
+	"sort"
+)
+
+/**
+ * Constants
+ */
+
+// Training zone methods.
+const (
+	// ZoneMaxHeartRate: zones as percentage of maximal heart rate.
+	ZoneMaxHeartRate int = iota
+	// ZoneKarvonen: zones as percentage of heart rate reserve.
+	ZoneKarvonen
+)
+
+/**
+ * Maximal heart rate equations
+ */
+
+// Maximal heart rate prediction for different equations.
+var (
+	NewFoxHeartRate     = FactoryHeartRate(foxConf)
+	NewTanakaHeartRate  = FactoryHeartRate(tanakaConf)
+	NewGellishHeartRate = FactoryHeartRate(gellishConf)
+	NewNesHeartRate     = FactoryHeartRate(nesConf)
+)
+
+/**
+ * Heart rate
+ */
+
+// HeartRate contains data needed to predict maximal heart rate and training
+// zones. This is a composition of a person, assessment details, resting
+// heart rate, the method used for training zones and an equation.
+type HeartRate struct {
+	*Person
+	*Assessment
+	Resting float64
+	Method  int
+	*EquationConf
+}
+
+// FactoryHeartRate factory to create new heart rate measurements. It returns
+// a function to create new HeartRate structs.
+func FactoryHeartRate(conf *EquationConf) func(*Person, *Assessment, float64, int) *HeartRate {
+	return func(p *Person, a *Assessment, resting float64, method int) *HeartRate {
+		return NewHeartRate(p, a, resting, method, conf)
+	}
+}
+
+// NewHeartRate create a new heart rate measurement. It receives a person, an
+// assessment, the resting heart rate, the training zone method, and the
+// equation used to predict maximal heart rate. Returns a pointer to
+// HeartRate.
+func NewHeartRate(p *Person, a *Assessment, resting float64, method int, e *EquationConf) *HeartRate {
+	return &HeartRate{p, a, resting, method, e}
+}
+
+func (h *HeartRate) String() string {
+	v, _ := h.Calc()
+	return fmt.Sprintf("HRmax: %.0f bpm", v)
+}
+
+// GetName returns this measurement name.
+func (h *HeartRate) GetName() string {
+	return "Heart rate"
+}
+
+// Result returns information about maximal heart rate and training zones.
+func (h *HeartRate) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := h.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	zones, err := h.Zones()
+	if err != nil {
+		return rs, err
+	}
+
+	rs = append(rs, fmt.Sprintf("HRmax (%s): %.0f bpm", h.EquationConf.Name, v))
+	if h.Resting > 0 {
+		rs = append(rs, fmt.Sprintf("HRrest: %.0f bpm", h.Resting))
+	}
+
+	keys := []int{}
+	for k := range zones {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	for _, k := range keys {
+		rs = append(rs, fmt.Sprintf(
+			"Zone %s (%.0f-%.0f%%): %.0f-%.0f bpm",
+			HRZoneClassification[k],
+			hrZoneIntensity[k][0]*100,
+			hrZoneIntensity[k][1]*100,
+			zones[k][0],
+			zones[k][1],
+		))
+	}
+	return rs, nil
+}
+
+// Calc returns the predicted maximal heart rate, in bpm.
+func (h *HeartRate) Calc() (float64, error) {
+	return h.equation().Calc()
+}
+
+// Reserve returns the heart rate reserve, the difference between maximal and
+// resting heart rate, in bpm.
+func (h *HeartRate) Reserve() (float64, error) {
+	v, err := h.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	if h.Resting <= 0 {
+		return 0.0, fmt.Errorf("Missing resting heart rate")
+	}
+	return v - h.Resting, nil
+}
+
+// Zones returns the training zones, mapping each zone constant to its lower
+// and upper heart rate limits, in bpm.
+func (h *HeartRate) Zones() (map[int][2]float64, error) {
+	v, err := h.Calc()
+	if err != nil {
+		return nil, err
+	}
+
+	base, reserve := 0.0, v
+	switch h.Method {
+	case ZoneMaxHeartRate:
+	case ZoneKarvonen:
+		if reserve, err = h.Reserve(); err != nil {
+			return nil, err
+		}
+		base = h.Resting
+	default:
+		return nil, fmt.Errorf("Unknown training zone method %d", h.Method)
+	}
+
+	zones := map[int][2]float64{}
+	for k, limits := range hrZoneIntensity {
+		zones[k] = [2]float64{base + limits[0]*reserve, base + limits[1]*reserve}
+	}
+	return zones, nil
+}
+
+// equation returns an equation, used to predict maximal heart rate.
+func (h *HeartRate) equation() Equationer {
+	return NewEquation(h.EquationConf.Extract(h), h.EquationConf)
+}
+
+/**
+ * Equations
+ */
+
+// Equations to predict maximal heart rate based on age.
+var (
+	foxConf     = newHeartRateConf("Fox", 220, 1)
+	tanakaConf  = newHeartRateConf("Tanaka", 208, 0.7)
+	gellishConf = newHeartRateConf("Gellish", 207, 0.7)
+	nesConf     = newHeartRateConf("Nes", 211, 0.64)
+)
+
+// newHeartRateConf returns an equation configuration for linear maximal heart
+// rate equations, with an intercept and an age coefficient.
+func newHeartRateConf(name string, intercept, coef float64) *EquationConf {
+	return NewEquationConf(
+		name,
+		func(i interface{}) InParams {
+			h := i.(*HeartRate)
+			return map[string]float64{
+				"age": h.Person.AgeFromDate(h.Assessment.Date),
+			}
+		},
+		[]Validator{
+			ValidateMeasures([]string{"age"}),
+		},
+		func(e *Equation) float64 {
+			age, _ := e.In("age")
+			return intercept - coef*age
+		},
+	)
+}
+
+/**
+ * Classification
+ */
+
+// Training zone constants.
+const (
+	HRZoneVeryLight = iota
+	HRZoneLight
+	HRZoneModerate
+	HRZoneHard
+	HRZoneMaximum
+)
+
+// HRZoneClassification map training zone constants to their string
+// representation.
+var HRZoneClassification = map[int]string{
+	HRZoneVeryLight: "Very light",
+	HRZoneLight:     "Light",
+	HRZoneModerate:  "Moderate",
+	HRZoneHard:      "Hard",
+	HRZoneMaximum:   "Maximum",
+}
+
+// hrZoneIntensity represents the intensity limits, as fraction of maximal
+// heart rate or heart rate reserve, for each training zone.
+var hrZoneIntensity = map[int][2]float64{
+	HRZoneVeryLight: {0.5, 0.6},
+	HRZoneLight:     {0.6, 0.7},
+	HRZoneModerate:  {0.7, 0.8},
+	HRZoneHard:      {0.8, 0.9},
+	HRZoneMaximum:   {0.9, 1.0},
+}
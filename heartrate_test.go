@@ -0,0 +1,87 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaxHeartRateCalc(t *testing.T) {
+	a, _ := NewAssessment("2018-Dec-15")
+
+	type hrCase struct {
+		hr   *HeartRate
+		calc float64
+	}
+
+	cases := []hrCase{
+		{NewFoxHeartRate(male, a, 0, ZoneMaxHeartRate), 180},
+		{NewTanakaHeartRate(male, a, 0, ZoneMaxHeartRate), 180},
+		{NewGellishHeartRate(male, a, 0, ZoneMaxHeartRate), 179},
+		{NewNesHeartRate(male, a, 0, ZoneMaxHeartRate), 185.4},
+		{NewTanakaHeartRate(female, a, 0, ZoneMaxHeartRate), 187},
+	}
+
+	for _, data := range cases {
+		if calc, err := data.hr.Calc(); err != nil {
+			t.Errorf("Equation %s should not fail: %s", data.hr.EquationConf.Name, err)
+		} else if !floatEqual(calc, data.calc, 0.001) {
+			t.Errorf("Equation %s calc is %.2f, expected is %.2f", data.hr.EquationConf.Name, calc, data.calc)
+		}
+	}
+}
+
+func TestHeartRateZones(t *testing.T) {
+	a, _ := NewAssessment("2018-Dec-15")
+
+	type zoneCase struct {
+		hr    *HeartRate
+		zones map[int][2]float64
+		err   string
+	}
+
+	cases := []zoneCase{
+		{
+			hr: NewFoxHeartRate(male, a, 60, ZoneMaxHeartRate),
+			zones: map[int][2]float64{
+				HRZoneVeryLight: {90, 108},
+				HRZoneModerate:  {126, 144},
+				HRZoneMaximum:   {162, 180},
+			},
+		},
+		{
+			hr: NewFoxHeartRate(male, a, 60, ZoneKarvonen),
+			zones: map[int][2]float64{
+				HRZoneVeryLight: {120, 132},
+				HRZoneModerate:  {144, 156},
+				HRZoneMaximum:   {168, 180},
+			},
+		},
+		{hr: NewFoxHeartRate(male, a, 0, ZoneKarvonen), err: "Missing resting heart rate"},
+		{hr: NewFoxHeartRate(male, a, 60, 42), err: "Unknown training zone method"},
+	}
+
+	for _, data := range cases {
+		zones, err := data.hr.Zones()
+		if data.err != "" {
+			if err == nil || !strings.Contains(err.Error(), data.err) {
+				t.Errorf("Should show proper error message, got %v", err)
+			}
+			if _, err := data.hr.Result(); err == nil {
+				t.Error("Result should show an error")
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Should not get an error: %s", err)
+		}
+		for k, limits := range data.zones {
+			if !floatEqual(zones[k][0], limits[0], 0.001) || !floatEqual(zones[k][1], limits[1], 0.001) {
+				t.Errorf("Zone %s is %v, expected is %v", HRZoneClassification[k], zones[k], limits)
+			}
+		}
+		if rs, err := data.hr.Result(); err != nil || len(rs) != 7 {
+			t.Errorf("Should have a result, instead got %v", err)
+		}
+	}
+}
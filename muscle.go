@@ -0,0 +1,320 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Muscle mass
+ */
+
+// MuscleMass contains data needed to estimate whole body skeletal muscle mass
+// from skinfold-corrected girths. This is a composition of a person,
+// assessment details, anthropometric data, skinfolds (triceps, thigh and
+// calf) and circumferences (right arm, thigh and calf). Ethnicity is the one
+// declared by the person.
+type MuscleMass struct {
+	*Person
+	*Assessment
+	*Anthropometry
+	*Skinfolds
+	*Circumferences
+}
+
+// NewMuscleMass returns a new MuscleMass instance.
+func NewMuscleMass(p *Person, a *Assessment, an *Anthropometry, s *Skinfolds, c *Circumferences) *MuscleMass {
+	return &MuscleMass{p, a, an, s, c}
+}
+
+func (m *MuscleMass) String() string {
+	v, _ := m.Calc()
+	return fmt.Sprintf("Skeletal muscle mass: %.2f kg", v)
+}
+
+// GetName returns this measurement name.
+func (m *MuscleMass) GetName() string {
+	return "Muscle mass"
+}
+
+// Result returns skeletal muscle mass and index.
+func (m *MuscleMass) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := m.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	i, _ := m.Index()
+	rs = append(
+		rs,
+		fmt.Sprintf("Skeletal muscle mass: %.2f kg.", v),
+		fmt.Sprintf("Skeletal muscle index: %.2f kg/m^2.", i),
+	)
+	return rs, nil
+}
+
+// Index returns the skeletal muscle mass adjusted by height squared.
+func (m *MuscleMass) Index() (float64, error) {
+	v, err := m.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	return v / math.Pow(m.Anthropometry.Height/100, 2), nil
+}
+
+// Calc returns the skeletal muscle mass, in kg.
+func (m *MuscleMass) Calc() (float64, error) {
+	return m.equation().Calc()
+}
+
+// equation returns an equation, used to calculate skeletal muscle mass.
+func (m *MuscleMass) equation() Equationer {
+	return NewEquation(leeConf.Extract(m), leeConf)
+}
+
+/**
+ * Appendicular muscle
+ */
+
+// AppendicularMuscle contains data needed to estimate appendicular skeletal
+// muscle mass. This is a composition of a person, anthropometric data,
+// circumferences (hip) and the handgrip strength, in kg.
+type AppendicularMuscle struct {
+	*Person
+	*Anthropometry
+	*Circumferences
+	Grip float64
+}
+
+// NewAppendicularMuscle returns a new AppendicularMuscle instance.
+func NewAppendicularMuscle(p *Person, an *Anthropometry, c *Circumferences, grip float64) *AppendicularMuscle {
+	return &AppendicularMuscle{p, an, c, grip}
+}
+
+func (m *AppendicularMuscle) String() string {
+	v, _ := m.Index()
+	c, _ := m.Classify()
+	return fmt.Sprintf("ASMI: %.2f kg/m^2 (%s)", v, c)
+}
+
+// GetName returns this measurement name.
+func (m *AppendicularMuscle) GetName() string {
+	return "Appendicular muscle"
+}
+
+// Result returns appendicular skeletal muscle mass, index, classification and
+// sarcopenia.
+func (m *AppendicularMuscle) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := m.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	c, err := m.Classify()
+	if err != nil {
+		return rs, err
+	}
+
+	i, _ := m.Index()
+	s, _ := m.Sarcopenia()
+	rs = append(
+		rs,
+		fmt.Sprintf("Appendicular skeletal muscle mass: %.2f kg.", v),
+		fmt.Sprintf("Appendicular skeletal muscle index: %.2f kg/m^2.", i),
+		fmt.Sprintf("Appendicular skeletal muscle classification: %s.", c),
+		fmt.Sprintf("Sarcopenia (EWGSOP2): %t.", s),
+	)
+	return rs, nil
+}
+
+// Index returns the appendicular skeletal muscle index (ASMI), as muscle mass
+// adjusted by height squared.
+func (m *AppendicularMuscle) Index() (float64, error) {
+	v, err := m.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	return v / math.Pow(m.Anthropometry.Height/100, 2), nil
+}
+
+// Classify returns the muscle mass classification, based in EWGSOP2 ASMI
+// cut-off points.
+func (m *AppendicularMuscle) Classify() (string, error) {
+	v, err := m.Index()
+	if err != nil {
+		return "", err
+	}
+
+	cutoff, ok := sarcopeniaASMICutoff[m.Person.Gender]
+	if !ok {
+		return "", fmt.Errorf("No cut-off for gender %d", m.Person.Gender)
+	}
+
+	return Classifier(v, newMuscleMassLimits(cutoff), MuscleMassClassification), nil
+}
+
+// Sarcopenia returns if both handgrip strength and ASMI are below EWGSOP2
+// cut-off points, confirming sarcopenia.
+func (m *AppendicularMuscle) Sarcopenia() (bool, error) {
+	c, err := m.Classify()
+	if err != nil {
+		return false, err
+	}
+	return c == MuscleMassClassification[MuscleMassLow] && m.Grip < sarcopeniaGripCutoff[m.Person.Gender], nil
+}
+
+// Calc returns the appendicular skeletal muscle mass, in kg.
+func (m *AppendicularMuscle) Calc() (float64, error) {
+	return m.equation().Calc()
+}
+
+// equation returns an equation, used to calculate appendicular skeletal
+// muscle mass.
+func (m *AppendicularMuscle) equation() Equationer {
+	return NewEquation(baumgartnerConf.Extract(m), baumgartnerConf)
+}
+
+/**
+ * Equations
+ */
+
+var (
+	// Lee et al. (2000), with girths corrected by skinfolds, in cm, and
+	// height in m.
+	leeConf = NewEquationConf(
+		"Lee skeletal muscle mass",
+		func(i interface{}) InParams {
+			m := i.(*MuscleMass)
+			r := m.Person.InParamsFromDate(m.Assessment.Date)
+			r["height"] = m.Anthropometry.Height
+			for _, k := range []int{SKFTriceps, SKFThigh, SKFCalf} {
+				if v, ok := m.Skinfolds.Measures[k]; ok {
+					r[NamedSkinfold(k)] = v
+				}
+			}
+			for _, k := range []int{CCFRightArm, CCFRightThigh, CCFRightCalf} {
+				if v, ok := m.Circumferences.Measures[k]; ok {
+					r[NamedCircumference(k)] = v
+				}
+			}
+			return r
+		},
+		[]Validator{
+			ValidateMeasures([]string{
+				"age",
+				"gender",
+				"ethnicity",
+				"height",
+				NamedSkinfold(SKFTriceps),
+				NamedSkinfold(SKFThigh),
+				NamedSkinfold(SKFCalf),
+				NamedCircumference(CCFRightArm),
+				NamedCircumference(CCFRightThigh),
+				NamedCircumference(CCFRightCalf),
+			}),
+			ValidateAge(20, 81),
+			func(e *Equation) (bool, error) {
+				v, _ := e.In("ethnicity")
+				if _, ok := leeEthnicity[int(v)]; !ok {
+					return false, fmt.Errorf("Unknown ethnicity %d", int(v))
+				}
+				return true, nil
+			},
+		},
+		func(e *Equation) float64 {
+			age, _ := e.In("age")
+			gender, _ := e.In("gender")
+			ethnicity, _ := e.In("ethnicity")
+			h, _ := e.In("height")
+			corrected := func(ccf, skf int) float64 {
+				c, _ := e.In(NamedCircumference(ccf))
+				s, _ := e.In(NamedSkinfold(skf))
+				return c - math.Pi*s/10
+			}
+			arm := corrected(CCFRightArm, SKFTriceps)
+			thigh := corrected(CCFRightThigh, SKFThigh)
+			calf := corrected(CCFRightCalf, SKFCalf)
+			sex := 0.0
+			if int(gender) == Male {
+				sex = 1.0
+			}
+			return h/100*(0.00744*math.Pow(arm, 2)+0.00088*math.Pow(thigh, 2)+0.00441*math.Pow(calf, 2)) +
+				2.4*sex - 0.048*age + leeEthnicity[int(ethnicity)] + 7.8
+		},
+	)
+	// Baumgartner et al. (1998), with height and hip in cm and grip in kg.
+	baumgartnerConf = NewEquationConf(
+		"Baumgartner appendicular skeletal muscle mass",
+		func(i interface{}) InParams {
+			m := i.(*AppendicularMuscle)
+			r := map[string]float64{
+				"gender": float64(m.Person.Gender),
+				"weight": m.Anthropometry.Weight,
+				"height": m.Anthropometry.Height,
+				"grip":   m.Grip,
+			}
+			if v, ok := m.Circumferences.Measures[CCFHip]; ok {
+				r[NamedCircumference(CCFHip)] = v
+			}
+			return r
+		},
+		[]Validator{
+			ValidateMeasures([]string{"gender", "weight", "height", "grip", NamedCircumference(CCFHip)}),
+		},
+		func(e *Equation) float64 {
+			gender, _ := e.In("gender")
+			w, _ := e.In("weight")
+			h, _ := e.In("height")
+			grip, _ := e.In("grip")
+			hip, _ := e.In(NamedCircumference(CCFHip))
+			sex := 0.0
+			if int(gender) == Male {
+				sex = 1.0
+			}
+			return 0.2487*w + 0.0483*h - 0.1584*hip + 0.0732*grip + 2.5843*sex + 5.8828
+		},
+	)
+)
+
+// leeEthnicity represents the ethnicity coefficient for Lee equation.
+var leeEthnicity = map[int]float64{
+	EthnicityWhite:           0,
+	EthnicityAfricanAmerican: 1.1,
+	EthnicityAsian:           -2.0,
+	EthnicityHispanic:        0,
+}
+
+/**
+ * Classification
+ */
+
+// Muscle mass classification constants.
+const (
+	MuscleMassLow = iota
+	MuscleMassNormal
+)
+
+// MuscleMassClassification map muscle mass constants to their string
+// representation.
+var MuscleMassClassification = map[int]string{
+	MuscleMassLow:    "Low muscle mass",
+	MuscleMassNormal: "Normal",
+}
+
+// newMuscleMassLimits returns muscle mass limits, based in the cut-off point.
+func newMuscleMassLimits(cutoff float64) map[int][2]float64 {
+	return map[int][2]float64{
+		MuscleMassLow:    {math.Inf(-1), cutoff},
+		MuscleMassNormal: {cutoff, math.Inf(+1)},
+	}
+}
+
+// sarcopeniaASMICutoff represents EWGSOP2 ASMI cut-off points, in kg/m^2.
+var sarcopeniaASMICutoff = map[int]float64{
+	Male:   7.0,
+	Female: 5.5,
+}
@@ -0,0 +1,117 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMuscleMass(t *testing.T) {
+	assessment, _ := NewAssessment("2016-Jan-01")
+	white, asian, black := *male, *male, *female
+	white.Ethnicity = EthnicityWhite
+	asian.Ethnicity = EthnicityAsian
+	black.Ethnicity = EthnicityAfricanAmerican
+
+	cases := []struct {
+		person *Person
+		height float64
+		skf    [3]float64
+		ccf    [3]float64
+		calc   float64
+		index  float64
+	}{
+		{&white, 175, [3]float64{10, 15, 10}, [3]float64{32, 55, 37}, 32.0089, 10.4519},
+		{&asian, 175, [3]float64{10, 15, 10}, [3]float64{32, 55, 37}, 30.0089, 9.7988},
+		{&black, 162, [3]float64{18, 25, 18}, [3]float64{28, 54, 35}, 22.8099, 8.6915},
+	}
+
+	for _, c := range cases {
+		m := NewMuscleMass(
+			c.person,
+			assessment,
+			NewAnthropometry(70, c.height),
+			NewSkinfolds(map[int]float64{SKFTriceps: c.skf[0], SKFThigh: c.skf[1], SKFCalf: c.skf[2]}),
+			NewCircumferences(map[int]float64{CCFRightArm: c.ccf[0], CCFRightThigh: c.ccf[1], CCFRightCalf: c.ccf[2]}),
+		)
+		if v, err := m.Calc(); err != nil || !floatEqual(v, c.calc, 0.0001) {
+			t.Errorf("Muscle mass expected %.4f, got %.4f (%v)", c.calc, v, err)
+		}
+		if v, _ := m.Index(); !floatEqual(v, c.index, 0.0001) {
+			t.Errorf("Muscle index expected %.4f, got %.4f", c.index, v)
+		}
+		if _, err := m.Result(); err != nil {
+			t.Errorf("Unexpected result error: %s", err)
+		}
+	}
+
+	young, _ := NewAssessment("1995-Jan-01")
+	unknown := white
+	unknown.Ethnicity = 10
+	errCases := []struct {
+		person     *Person
+		assessment *Assessment
+		skinfolds  map[int]float64
+		err        string
+	}{
+		{&white, assessment, map[int]float64{SKFTriceps: 10, SKFThigh: 15}, "Missing calf measure"},
+		{&white, young, map[int]float64{SKFTriceps: 10, SKFThigh: 15, SKFCalf: 10}, "Valid for ages between 20 and 81"},
+		{&unknown, assessment, map[int]float64{SKFTriceps: 10, SKFThigh: 15, SKFCalf: 10}, "Unknown ethnicity 10"},
+		{male, assessment, map[int]float64{SKFTriceps: 10, SKFThigh: 15, SKFCalf: 10}, "Missing ethnicity measure"},
+	}
+	for _, c := range errCases {
+		m := NewMuscleMass(
+			c.person,
+			c.assessment,
+			NewAnthropometry(70, 175),
+			NewSkinfolds(c.skinfolds),
+			NewCircumferences(map[int]float64{CCFRightArm: 32, CCFRightThigh: 55, CCFRightCalf: 37}),
+		)
+		if _, err := m.Calc(); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("Expected error containing %q, got %v", c.err, err)
+		}
+	}
+}
+
+func TestAppendicularMuscle(t *testing.T) {
+	cases := []struct {
+		person     *Person
+		weight     float64
+		height     float64
+		hip        float64
+		grip       float64
+		calc       float64
+		index      float64
+		class      int
+		sarcopenia bool
+	}{
+		{male, 75, 175, 98, 40, 22.9769, 7.5027, MuscleMassNormal, false},
+		{male, 60, 170, 105, 20, 16.4321, 5.6858, MuscleMassLow, true},
+		{male, 60, 170, 105, 30, 17.1641, 5.9391, MuscleMassLow, false},
+		{female, 55, 160, 98, 14, 12.7909, 4.9964, MuscleMassLow, true},
+		{female, 60, 162, 98, 25, 14.9362, 5.6913, MuscleMassNormal, false},
+	}
+
+	for _, c := range cases {
+		m := NewAppendicularMuscle(c.person, NewAnthropometry(c.weight, c.height), NewCircumferences(map[int]float64{CCFHip: c.hip}), c.grip)
+		if v, err := m.Calc(); err != nil || !floatEqual(v, c.calc, 0.0001) {
+			t.Errorf("ASM expected %.4f, got %.4f (%v)", c.calc, v, err)
+		}
+		if v, _ := m.Index(); !floatEqual(v, c.index, 0.0001) {
+			t.Errorf("ASMI expected %.4f, got %.4f", c.index, v)
+		}
+		if v, _ := m.Classify(); v != MuscleMassClassification[c.class] {
+			t.Errorf("Classification expected %s, got %s", MuscleMassClassification[c.class], v)
+		}
+		if v, _ := m.Sarcopenia(); v != c.sarcopenia {
+			t.Errorf("Sarcopenia expected %t, got %t", c.sarcopenia, v)
+		}
+		if _, err := m.Result(); err != nil {
+			t.Errorf("Unexpected result error: %s", err)
+		}
+	}
+
+	m := NewAppendicularMuscle(male, NewAnthropometry(75, 175), NewCircumferences(map[int]float64{}), 40)
+	if _, err := m.Result(); err == nil || !strings.Contains(err.Error(), "Missing hip measure") {
+		t.Errorf("Expected missing hip error, got %v", err)
+	}
+}
@@ -0,0 +1,302 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/**
+ * Constants
+ */
+
+// Senior Fitness Test items constants.
+const (
+	// SFTChairStand: 30-second chair stand, in repetitions.
+	SFTChairStand int = iota
+	// SFTArmCurl: 30-second arm curl, in repetitions.
+	SFTArmCurl
+	// SFTSixMinuteWalk: 6-minute walk, in meters.
+	SFTSixMinuteWalk
+	// SFTTwoMinuteStep: 2-minute step, in steps.
+	SFTTwoMinuteStep
+	// SFTChairSitAndReach: chair sit-and-reach, in cm.
+	SFTChairSitAndReach
+	// SFTBackScratch: back scratch, in cm.
+	SFTBackScratch
+	// SFTUpAndGo: 8-foot up-and-go, in seconds.
+	SFTUpAndGo
+)
+
+/**
+ * Senior Fitness Test
+ */
+
+// SeniorFitness represents the Senior Fitness Test battery, from Rikli and
+// Jones, with each item scored against age and gender norms, for older
+// adults between 60 and 94 years.
+type SeniorFitness struct {
+	*Person
+	*Assessment
+	Items map[int]float64
+}
+
+// NewSeniorFitness creates a new Senior Fitness Test battery, based in
+// person, assessment and items measures.
+func NewSeniorFitness(person *Person, assessment *Assessment, items map[int]float64) *SeniorFitness {
+	return &SeniorFitness{person, assessment, items}
+}
+
+func (s *SeniorFitness) String() string {
+	v, _ := s.Calc()
+	c, _ := s.Classify()
+	return fmt.Sprintf("Senior Fitness Test: %.0f percentile (%s)", v, c)
+}
+
+// GetName returns this measurement name.
+func (s *SeniorFitness) GetName() string {
+	return "Senior Fitness Test"
+}
+
+// Result returns the functional fitness profile, with percentile and
+// classification for each item, and the overall profile.
+func (s *SeniorFitness) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := s.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	c, err := s.Classify()
+	if err != nil {
+		return rs, err
+	}
+
+	items := []int{}
+	for k := range s.Items {
+		items = append(items, k)
+	}
+	sort.Ints(items)
+
+	for _, k := range items {
+		p, err := s.Percentile(k)
+		if err != nil {
+			return []string{}, err
+		}
+		rs = append(rs, fmt.Sprintf(
+			"%s: %.1f (%.0f percentile, %s).",
+			NamedSeniorFitnessItem(k),
+			s.Items[k],
+			p,
+			Classifier(p, sftLimits, SFTClassification),
+		))
+	}
+
+	rs = append(rs, fmt.Sprintf("Functional fitness profile: %.0f percentile (%s).", v, c))
+	return rs, nil
+}
+
+// Percentile returns the estimated percentile for a given item, assuming a
+// normal distribution around the published normal range (25th to 75th
+// percentile) for this person age and gender.
+func (s *SeniorFitness) Percentile(item int) (float64, error) {
+	e := NewEquation(sftConf.Extract(s), sftConf)
+	if ok, err := e.Validate(); !ok {
+		return 0.0, err
+	}
+
+	v, ok := e.In(NamedSeniorFitnessItem(item))
+	if !ok {
+		return 0.0, fmt.Errorf("Missing %s measure", NamedSeniorFitnessItem(item))
+	}
+	gender, _ := e.In("gender")
+	age, _ := e.In("age")
+	return sftPercentile(item, int(gender), age, v)
+}
+
+// ClassifyItem returns the classification for a given item.
+func (s *SeniorFitness) ClassifyItem(item int) (string, error) {
+	p, err := s.Percentile(item)
+	if err != nil {
+		return "", err
+	}
+	return Classifier(p, sftLimits, SFTClassification), nil
+}
+
+// Classify returns the classification for the overall profile.
+func (s *SeniorFitness) Classify() (string, error) {
+	v, err := s.Calc()
+	if err != nil {
+		return "", err
+	}
+	return Classifier(v, sftLimits, SFTClassification), nil
+}
+
+// Calc returns the overall profile, as the mean percentile of all items.
+func (s *SeniorFitness) Calc() (float64, error) {
+	return s.equation().Calc()
+}
+
+// equation returns an equation, used to calculate the overall profile.
+func (s *SeniorFitness) equation() Equationer {
+	return NewEquation(sftConf.Extract(s), sftConf)
+}
+
+// NamedSeniorFitnessItem returns the name for a given Senior Fitness Test
+// item constant.
+func NamedSeniorFitnessItem(item int) string {
+	named := map[int]string{
+		SFTChairStand:       "chair stand",
+		SFTArmCurl:          "arm curl",
+		SFTSixMinuteWalk:    "6-minute walk",
+		SFTTwoMinuteStep:    "2-minute step",
+		SFTChairSitAndReach: "chair sit-and-reach",
+		SFTBackScratch:      "back scratch",
+		SFTUpAndGo:          "8-foot up-and-go",
+	}
+	return named[item]
+}
+
+/**
+ * Equation
+ */
+
+var sftConf = NewEquationConf(
+	"Senior Fitness Test",
+	func(i interface{}) InParams {
+		s := i.(*SeniorFitness)
+		r := map[string]float64{
+			"age":    s.Person.AgeFromDate(s.Assessment.Date),
+			"gender": float64(s.Person.Gender),
+		}
+		for k, v := range s.Items {
+			if _, ok := sftNorms[k]; ok {
+				r[NamedSeniorFitnessItem(k)] = v
+			}
+		}
+		return r
+	},
+	[]Validator{
+		ValidateMeasures([]string{"age", "gender"}),
+		ValidateAge(60, 94),
+		func(e *Equation) (bool, error) {
+			for k := range sftNorms {
+				if _, ok := e.In(NamedSeniorFitnessItem(k)); ok {
+					return true, nil
+				}
+			}
+			return false, fmt.Errorf("Missing Senior Fitness Test items")
+		},
+	},
+	func(e *Equation) float64 {
+		gender, _ := e.In("gender")
+		age, _ := e.In("age")
+		accum, count := 0.0, 0.0
+		for k := range sftNorms {
+			v, ok := e.In(NamedSeniorFitnessItem(k))
+			if !ok {
+				continue
+			}
+			p, _ := sftPercentile(k, int(gender), age, v)
+			accum += p
+			count++
+		}
+		return accum / count
+	},
+)
+
+// sftPercentile returns the estimated percentile for an item value, with
+// base in the normal range for gender and age.
+func sftPercentile(item, gender int, age, value float64) (float64, error) {
+	limits, ok := sftNorms[item][gender]
+	if !ok {
+		return 0.0, fmt.Errorf("No norms for gender %d", gender)
+	}
+
+	for ages, normal := range limits {
+		if age < ages[0] || age >= ages[1] {
+			continue
+		}
+		mean := (normal[0] + normal[1]) / 2
+		sd := (normal[1] - normal[0]) / (2 * 0.6745)
+		z := (value - mean) / sd
+		return 50 * (1 + math.Erf(z/math.Sqrt2)), nil
+	}
+
+	return 0.0, fmt.Errorf("No norms for age %.0f", age)
+}
+
+/**
+ * Classification
+ */
+
+// Senior Fitness Test classification constants.
+const (
+	SFTBelowNormal = iota
+	SFTNormal
+	SFTAboveNormal
+)
+
+// SFTClassification map classification constants to their string
+// representation.
+var SFTClassification = map[int]string{
+	SFTBelowNormal: "Below normal range",
+	SFTNormal:      "Normal range",
+	SFTAboveNormal: "Above normal range",
+}
+
+// sftLimits represent the percentile limits for each classification.
+var sftLimits = map[int][2]float64{
+	SFTBelowNormal: {math.Inf(-1), 25},
+	SFTNormal:      {25, 75},
+	SFTAboveNormal: {75, math.Inf(+1)},
+}
+
+// yard converts yards to meters.
+const yard = 0.9144
+
+// newSeniorNorms returns the normal range (25th and 75th percentile scores)
+// for each 5 year age band, from 60 to 94 years, converted by unit.
+func newSeniorNorms(unit float64, ranges ...[2]float64) map[[2]float64][2]float64 {
+	norms := map[[2]float64][2]float64{}
+	for i, r := range ranges {
+		lower := 60 + 5*float64(i)
+		norms[[2]float64{lower, lower + 5}] = [2]float64{r[0] * unit, r[1] * unit}
+	}
+	return norms
+}
+
+// sftNorms represent the normal range for each item, gender and age band, from
+// Rikli and Jones. For the up-and-go the 25th percentile score is the slower
+// time.
+var sftNorms = map[int]map[int]map[[2]float64][2]float64{
+	SFTChairStand: {
+		Male:   newSeniorNorms(1, [2]float64{14, 19}, [2]float64{12, 18}, [2]float64{12, 17}, [2]float64{11, 17}, [2]float64{10, 15}, [2]float64{8, 14}, [2]float64{7, 12}),
+		Female: newSeniorNorms(1, [2]float64{12, 17}, [2]float64{11, 16}, [2]float64{10, 15}, [2]float64{10, 15}, [2]float64{9, 14}, [2]float64{8, 13}, [2]float64{4, 11}),
+	},
+	SFTArmCurl: {
+		Male:   newSeniorNorms(1, [2]float64{16, 22}, [2]float64{15, 21}, [2]float64{14, 21}, [2]float64{13, 19}, [2]float64{13, 19}, [2]float64{11, 17}, [2]float64{10, 14}),
+		Female: newSeniorNorms(1, [2]float64{13, 19}, [2]float64{12, 18}, [2]float64{12, 17}, [2]float64{11, 17}, [2]float64{10, 16}, [2]float64{10, 15}, [2]float64{8, 13}),
+	},
+	SFTSixMinuteWalk: {
+		Male:   newSeniorNorms(yard, [2]float64{610, 735}, [2]float64{560, 700}, [2]float64{545, 680}, [2]float64{470, 640}, [2]float64{445, 605}, [2]float64{380, 570}, [2]float64{305, 500}),
+		Female: newSeniorNorms(yard, [2]float64{545, 660}, [2]float64{500, 635}, [2]float64{480, 615}, [2]float64{435, 585}, [2]float64{385, 540}, [2]float64{340, 510}, [2]float64{275, 440}),
+	},
+	SFTTwoMinuteStep: {
+		Male:   newSeniorNorms(1, [2]float64{87, 115}, [2]float64{86, 116}, [2]float64{80, 110}, [2]float64{73, 109}, [2]float64{71, 103}, [2]float64{59, 91}, [2]float64{52, 86}),
+		Female: newSeniorNorms(1, [2]float64{75, 107}, [2]float64{73, 107}, [2]float64{68, 101}, [2]float64{68, 100}, [2]float64{60, 90}, [2]float64{55, 85}, [2]float64{44, 72}),
+	},
+	SFTChairSitAndReach: {
+		Male:   newSeniorNorms(inch, [2]float64{-2.5, 4.0}, [2]float64{-3.0, 3.0}, [2]float64{-3.0, 3.0}, [2]float64{-4.0, 2.0}, [2]float64{-5.5, 1.5}, [2]float64{-5.5, 0.5}, [2]float64{-6.5, -0.5}),
+		Female: newSeniorNorms(inch, [2]float64{-0.5, 5.0}, [2]float64{-0.5, 4.5}, [2]float64{-1.0, 4.0}, [2]float64{-1.5, 3.5}, [2]float64{-2.0, 3.0}, [2]float64{-2.5, 2.5}, [2]float64{-4.5, 1.0}),
+	},
+	SFTBackScratch: {
+		Male:   newSeniorNorms(inch, [2]float64{-6.5, 0.0}, [2]float64{-7.5, -1.0}, [2]float64{-8.0, -1.0}, [2]float64{-9.0, -2.0}, [2]float64{-9.5, -2.0}, [2]float64{-10.0, -3.0}, [2]float64{-10.5, -4.0}),
+		Female: newSeniorNorms(inch, [2]float64{-3.0, 1.5}, [2]float64{-3.5, 1.5}, [2]float64{-4.0, 1.0}, [2]float64{-5.0, 0.5}, [2]float64{-5.5, 0.0}, [2]float64{-7.0, -1.0}, [2]float64{-8.0, -1.0}),
+	},
+	SFTUpAndGo: {
+		Male:   newSeniorNorms(1, [2]float64{5.6, 3.8}, [2]float64{5.9, 4.3}, [2]float64{6.2, 4.4}, [2]float64{7.2, 4.6}, [2]float64{7.6, 5.2}, [2]float64{8.9, 5.5}, [2]float64{10.0, 6.2}),
+		Female: newSeniorNorms(1, [2]float64{6.0, 4.4}, [2]float64{6.4, 4.8}, [2]float64{7.1, 4.9}, [2]float64{7.4, 5.2}, [2]float64{8.7, 5.7}, [2]float64{9.6, 6.2}, [2]float64{11.5, 7.3}),
+	},
+}
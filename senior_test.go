@@ -0,0 +1,93 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSeniorFitnessCalcAndClassification(t *testing.T) {
+	a, _ := NewAssessment("2043-Dec-15")
+	sft := NewSeniorFitness(male, a, map[int]float64{
+		SFTChairStand:    15,
+		SFTArmCurl:       22,
+		SFTSixMinuteWalk: 630 * yard,
+		SFTUpAndGo:       4.0,
+		SFTBackScratch:   -25,
+	})
+
+	type itemCase struct {
+		item       int
+		percentile float64
+		classify   string
+	}
+
+	cases := []itemCase{
+		{SFTChairStand, 50.0, SFTClassification[SFTNormal]},
+		{SFTArmCurl, 81.576, SFTClassification[SFTAboveNormal]},
+		{SFTSixMinuteWalk, 50.0, SFTClassification[SFTNormal]},
+		{SFTUpAndGo, 82.315, SFTClassification[SFTAboveNormal]},
+		{SFTBackScratch, 12.289, SFTClassification[SFTBelowNormal]},
+	}
+
+	for _, data := range cases {
+		name := NamedSeniorFitnessItem(data.item)
+		if p, err := sft.Percentile(data.item); err != nil {
+			t.Errorf("Item %s should not get an error: %s", name, err)
+		} else if !floatEqual(p, data.percentile, 0.001) {
+			t.Errorf("Item %s percentile is %.3f, expected is %.3f", name, p, data.percentile)
+		}
+		if c, _ := sft.ClassifyItem(data.item); c != data.classify {
+			t.Errorf("Item %s classify is %s, expected is %s", name, c, data.classify)
+		}
+	}
+
+	if v, err := sft.Calc(); err != nil {
+		t.Errorf("Should not get an error: %s", err)
+	} else if !floatEqual(v, 55.236, 0.001) {
+		t.Errorf("Profile is %.3f, expected is %.3f", v, 55.236)
+	}
+	if c, _ := sft.Classify(); c != SFTClassification[SFTNormal] {
+		t.Errorf("Profile classify is %s, expected is %s", c, SFTClassification[SFTNormal])
+	}
+	if rs, err := sft.Result(); err != nil || len(rs) != 6 {
+		t.Errorf("Should have a result, instead got %v", err)
+	}
+	if _, err := sft.Percentile(SFTTwoMinuteStep); err == nil || !strings.Contains(err.Error(), "Missing 2-minute step") {
+		t.Errorf("Should show proper error message, got %v", err)
+	}
+}
+
+func TestSeniorFitnessFemale(t *testing.T) {
+	a, _ := NewAssessment("2063-Mar-15")
+	sft := NewSeniorFitness(female, a, map[int]float64{SFTChairStand: 12, SFTUpAndGo: 6})
+
+	if v, err := sft.Calc(); err != nil {
+		t.Errorf("Should not get an error: %s", err)
+	} else if !floatEqual(v, (44.635+57.298)/2, 0.001) {
+		t.Errorf("Profile is %.3f, expected is %.3f", v, (44.635+57.298)/2)
+	}
+}
+
+func TestSeniorFitnessInvalid(t *testing.T) {
+	a, _ := NewAssessment("2015-May-22")
+	old, _ := NewAssessment("2043-Dec-15")
+
+	type sftCase struct {
+		sft *SeniorFitness
+		err string
+	}
+
+	cases := []sftCase{
+		{NewSeniorFitness(male, a, map[int]float64{SFTChairStand: 15}), "Valid for ages"},
+		{NewSeniorFitness(male, old, map[int]float64{}), "Missing Senior Fitness Test items"},
+	}
+
+	for _, data := range cases {
+		if _, err := data.sft.Classify(); err == nil || !strings.Contains(err.Error(), data.err) {
+			t.Errorf("Should show proper error message %s, got %v", data.err, err)
+		}
+		if _, err := data.sft.Result(); err == nil {
+			t.Error("Result should show an error")
+		}
+	}
+}
@@ -24,8 +24,10 @@ const (
 	SKFAbdominal
 	// SKFThigh: thigh skinfold.
 	SKFThigh
-	// SKFCalf: calf skinfold.
+	// SKFCalf: medial calf skinfold.
 	SKFCalf
+	// SKFSupraspinale: supraspinale skinfold.
+	SKFSupraspinale
 )
 
 /*
@@ -82,15 +84,16 @@ func (s *Skinfolds) SumSpecific(skinfolds []int) float64 {
 // NamedSkinfold returns the name for a given skinfold constant.
 func NamedSkinfold(name int) string {
 	named := map[int]string{
-		SKFSubscapular: "subscapular",
-		SKFTriceps:     "triceps",
-		SKFBiceps:      "biceps",
-		SKFChest:       "chest",
-		SKFMidaxillary: "mid-axillary",
-		SKFSuprailiac:  "suprailiac",
-		SKFAbdominal:   "abdominal",
-		SKFThigh:       "thigh",
-		SKFCalf:        "calf",
+		SKFSubscapular:  "subscapular",
+		SKFTriceps:      "triceps",
+		SKFBiceps:       "biceps",
+		SKFChest:        "chest",
+		SKFMidaxillary:  "mid-axillary",
+		SKFSuprailiac:   "suprailiac",
+		SKFAbdominal:    "abdominal",
+		SKFThigh:        "thigh",
+		SKFCalf:         "calf",
+		SKFSupraspinale: "supraspinale",
 	}
 
 	return named[name]
@@ -0,0 +1,273 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/**
+ * Somatotype
+ */
+
+// Somatotype represents the Heath-Carter anthropometric somatotype. This is a
+// composition of anthropometric data, skinfolds (triceps, subscapular,
+// supraspinale and calf), circumferences (right arm, flexed and tensed, as
+// CCFRightFlexedArm, and right calf) and bone breadths (humerus and femur).
+type Somatotype struct {
+	*Anthropometry
+	*Skinfolds
+	*Circumferences
+	*Breadths
+}
+
+// NewSomatotype returns a new Somatotype instance.
+func NewSomatotype(a *Anthropometry, s *Skinfolds, c *Circumferences, b *Breadths) *Somatotype {
+	return &Somatotype{a, s, c, b}
+}
+
+func (s *Somatotype) String() string {
+	c, _ := s.Components()
+	n, _ := s.Classify()
+	return fmt.Sprintf("Somatotype: %.1f-%.1f-%.1f (%s)", c[0], c[1], c[2], n)
+}
+
+// GetName returns this measurement name.
+func (s *Somatotype) GetName() string {
+	return "Somatotype"
+}
+
+// Result returns the somatotype components, somatochart coordinates and
+// category.
+func (s *Somatotype) Result() ([]string, error) {
+	rs := []string{}
+
+	c, err := s.Components()
+	if err != nil {
+		return rs, err
+	}
+
+	x, y, _ := s.Somatochart()
+	n, _ := s.Classify()
+	rs = append(
+		rs,
+		fmt.Sprintf("Endomorphy: %.1f.", c[0]),
+		fmt.Sprintf("Mesomorphy: %.1f.", c[1]),
+		fmt.Sprintf("Ectomorphy: %.1f.", c[2]),
+		fmt.Sprintf("Somatochart: X %.1f, Y %.1f.", x, y),
+		fmt.Sprintf("Somatotype category: %s.", n),
+	)
+	return rs, nil
+}
+
+// Endomorphy returns the relative fatness component.
+func (s *Somatotype) Endomorphy() (float64, error) {
+	return s.equation(endomorphyConf).Calc()
+}
+
+// Mesomorphy returns the relative musculo-skeletal robustness component.
+func (s *Somatotype) Mesomorphy() (float64, error) {
+	return s.equation(mesomorphyConf).Calc()
+}
+
+// Ectomorphy returns the relative linearity component.
+func (s *Somatotype) Ectomorphy() (float64, error) {
+	return s.equation(ectomorphyConf).Calc()
+}
+
+// Components returns endomorphy, mesomorphy and ectomorphy, in this order.
+func (s *Somatotype) Components() ([3]float64, error) {
+	c := [3]float64{}
+	for i, f := range []func() (float64, error){s.Endomorphy, s.Mesomorphy, s.Ectomorphy} {
+		v, err := f()
+		if err != nil {
+			return c, err
+		}
+		c[i] = v
+	}
+	return c, nil
+}
+
+// Somatochart returns the X and Y coordinates used to plot the somatotype.
+func (s *Somatotype) Somatochart() (float64, float64, error) {
+	c, err := s.Components()
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	return c[2] - c[0], 2*c[1] - (c[0] + c[2]), nil
+}
+
+// Classify returns the somatotype category, based in the dominance among
+// components. Components are considered equal when they differ by no more
+// than half unit.
+func (s *Somatotype) Classify() (string, error) {
+	c, err := s.Components()
+	if err != nil {
+		return "", err
+	}
+
+	if math.Max(c[0], math.Max(c[1], c[2]))-math.Min(c[0], math.Min(c[1], c[2])) <= 1 {
+		return SomatotypeClassification[SomatotypeCentral], nil
+	}
+
+	order := []int{SomatotypeEndomorph, SomatotypeMesomorph, SomatotypeEctomorph}
+	sort.SliceStable(order, func(i, j int) bool { return c[order[i]] > c[order[j]] })
+	high, mid, low := order[0], order[1], order[2]
+
+	if c[high]-c[mid] <= 0.5 {
+		return somatotypeEqualNames[[2]int{high, mid}], nil
+	}
+	if c[mid]-c[low] <= 0.5 {
+		return fmt.Sprintf("Balanced %s", somatotypeNouns[high]), nil
+	}
+	return fmt.Sprintf("%s %s", somatotypeAdjectives[mid], somatotypeNouns[high]), nil
+}
+
+// equation returns an equation, used to calculate a somatotype component.
+func (s *Somatotype) equation(conf *EquationConf) Equationer {
+	return NewEquation(conf.Extract(s), conf)
+}
+
+/**
+ * Equations
+ */
+
+// Heath-Carter equations for each somatotype component.
+var (
+	endomorphyConf = NewEquationConf(
+		"Endomorphy",
+		somatotypeInParams,
+		[]Validator{
+			ValidateMeasures([]string{
+				"height",
+				NamedSkinfold(SKFTriceps),
+				NamedSkinfold(SKFSubscapular),
+				NamedSkinfold(SKFSupraspinale),
+			}),
+		},
+		func(e *Equation) float64 {
+			h, _ := e.In("height")
+			tr, _ := e.In(NamedSkinfold(SKFTriceps))
+			sb, _ := e.In(NamedSkinfold(SKFSubscapular))
+			ss, _ := e.In(NamedSkinfold(SKFSupraspinale))
+			x := (tr + sb + ss) * 170.18 / h
+			return -0.7182 + 0.1451*x - 0.00068*math.Pow(x, 2) + 0.0000014*math.Pow(x, 3)
+		},
+	)
+	mesomorphyConf = NewEquationConf(
+		"Mesomorphy",
+		somatotypeInParams,
+		[]Validator{
+			ValidateMeasures([]string{
+				"height",
+				NamedSkinfold(SKFTriceps),
+				NamedSkinfold(SKFCalf),
+				NamedCircumference(CCFRightFlexedArm),
+				NamedCircumference(CCFRightCalf),
+				NamedBreadth(BDHumerus),
+				NamedBreadth(BDFemur),
+			}),
+		},
+		func(e *Equation) float64 {
+			h, _ := e.In("height")
+			tr, _ := e.In(NamedSkinfold(SKFTriceps))
+			cf, _ := e.In(NamedSkinfold(SKFCalf))
+			arm, _ := e.In(NamedCircumference(CCFRightFlexedArm))
+			calf, _ := e.In(NamedCircumference(CCFRightCalf))
+			hb, _ := e.In(NamedBreadth(BDHumerus))
+			fb, _ := e.In(NamedBreadth(BDFemur))
+			// girths are corrected by the skinfold, converted from mm to cm.
+			return 0.858*hb + 0.601*fb + 0.188*(arm-tr/10) + 0.161*(calf-cf/10) - 0.131*h + 4.5
+		},
+	)
+	ectomorphyConf = NewEquationConf(
+		"Ectomorphy",
+		somatotypeInParams,
+		[]Validator{
+			ValidateMeasures([]string{"weight", "height"}),
+		},
+		func(e *Equation) float64 {
+			w, _ := e.In("weight")
+			h, _ := e.In("height")
+			hwr := h / math.Cbrt(w)
+			switch {
+			case hwr >= 40.75:
+				return 0.732*hwr - 28.58
+			case hwr > 38.25:
+				return 0.463*hwr - 17.63
+			}
+			return 0.1
+		},
+	)
+)
+
+// somatotypeInParams returns weight, height, and available skinfolds,
+// circumferences and bone breadths from a Somatotype. Measures not provided
+// are left out, and reported as missing by the validators.
+func somatotypeInParams(i interface{}) InParams {
+	s := i.(*Somatotype)
+	r := map[string]float64{}
+	if s.Anthropometry != nil {
+		r["weight"] = s.Anthropometry.Weight
+		r["height"] = s.Anthropometry.Height
+	}
+	if s.Skinfolds != nil {
+		for k, v := range s.Skinfolds.Measures {
+			r[NamedSkinfold(k)] = v
+		}
+	}
+	if s.Circumferences != nil {
+		for k, v := range s.Circumferences.Measures {
+			r[NamedCircumference(k)] = v
+		}
+	}
+	if s.Breadths != nil {
+		for k, v := range s.Breadths.Measures {
+			r[NamedBreadth(k)] = v
+		}
+	}
+	return r
+}
+
+/**
+ * Classification
+ */
+
+// Somatotype component constants, used as index for components.
+const (
+	SomatotypeEndomorph = iota
+	SomatotypeMesomorph
+	SomatotypeEctomorph
+	SomatotypeCentral
+)
+
+// SomatotypeClassification map somatotype constants to their string
+// representation.
+var SomatotypeClassification = map[int]string{
+	SomatotypeEndomorph: "Endomorph",
+	SomatotypeMesomorph: "Mesomorph",
+	SomatotypeEctomorph: "Ectomorph",
+	SomatotypeCentral:   "Central",
+}
+
+// Names used to compose somatotype categories.
+var (
+	somatotypeNouns = map[int]string{
+		SomatotypeEndomorph: "endomorph",
+		SomatotypeMesomorph: "mesomorph",
+		SomatotypeEctomorph: "ectomorph",
+	}
+	somatotypeAdjectives = map[int]string{
+		SomatotypeEndomorph: "Endomorphic",
+		SomatotypeMesomorph: "Mesomorphic",
+		SomatotypeEctomorph: "Ectomorphic",
+	}
+	somatotypeEqualNames = map[[2]int]string{
+		{SomatotypeEndomorph, SomatotypeMesomorph}: "Mesomorph-endomorph",
+		{SomatotypeMesomorph, SomatotypeEndomorph}: "Mesomorph-endomorph",
+		{SomatotypeMesomorph, SomatotypeEctomorph}: "Mesomorph-ectomorph",
+		{SomatotypeEctomorph, SomatotypeMesomorph}: "Mesomorph-ectomorph",
+		{SomatotypeEndomorph, SomatotypeEctomorph}: "Endomorph-ectomorph",
+		{SomatotypeEctomorph, SomatotypeEndomorph}: "Endomorph-ectomorph",
+	}
+)
@@ -0,0 +1,89 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSomatotype(t *testing.T) {
+	newSomatotype := func(weight, height float64, skf [4]float64, ccf [2]float64, bd [2]float64) *Somatotype {
+		return NewSomatotype(
+			NewAnthropometry(weight, height),
+			NewSkinfolds(map[int]float64{SKFTriceps: skf[0], SKFSubscapular: skf[1], SKFSupraspinale: skf[2], SKFCalf: skf[3]}),
+			NewCircumferences(map[int]float64{CCFRightFlexedArm: ccf[0], CCFRightCalf: ccf[1]}),
+			NewBreadths(map[int]float64{BDHumerus: bd[0], BDFemur: bd[1]}),
+		)
+	}
+
+	cases := []struct {
+		somatotype *Somatotype
+		components [3]float64
+		x, y       float64
+		category   string
+	}{
+		{newSomatotype(70, 175, [4]float64{10, 12, 8, 8}, [2]float64{32, 36}, [2]float64{7.0, 9.7}), [3]float64{2.9709, 4.9059, 2.5025}, -0.4684, 4.3383, "Balanced mesomorph"},
+		{newSomatotype(80, 165, [4]float64{22, 28, 25, 20}, [2]float64{31, 37}, [2]float64{6.6, 9.3}), [3]float64{7.085, 5.1865, 0.0997}, -6.9853, 3.1883, "Mesomorphic endomorph"},
+		{newSomatotype(65, 185, [4]float64{5, 6, 4, 4}, [2]float64{27, 33}, [2]float64{6.5, 9.0}), [3]float64{1.1582, 1.4816, 5.1005}, 3.9423, -3.2954, "Balanced ectomorph"},
+		{newSomatotype(70, 170, [4]float64{10, 12, 9, 9}, [2]float64{31, 36}, [2]float64{6.8, 9.4}), [3]float64{3.1716, 5.0049, 1.6145}, -1.5572, 5.2237, "Endomorphic mesomorph"},
+		{newSomatotype(80, 165, [4]float64{14, 18, 15, 20}, [2]float64{31, 37}, [2]float64{6.6, 9.3}), [3]float64{4.8772, 5.3369, 0.0997}, -4.7775, 5.6969, "Mesomorph-endomorph"},
+	}
+
+	for _, c := range cases {
+		components, err := c.somatotype.Components()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		for i := range components {
+			if !floatEqual(components[i], c.components[i], 0.001) {
+				t.Errorf("Component %d expected %.4f, got %.4f", i, c.components[i], components[i])
+			}
+		}
+
+		x, y, _ := c.somatotype.Somatochart()
+		if !floatEqual(x, c.x, 0.001) || !floatEqual(y, c.y, 0.001) {
+			t.Errorf("Somatochart expected (%.4f, %.4f), got (%.4f, %.4f)", c.x, c.y, x, y)
+		}
+
+		if category, _ := c.somatotype.Classify(); category != c.category {
+			t.Errorf("Category expected %s, got %s", c.category, category)
+		}
+
+		if _, err := c.somatotype.Result(); err != nil {
+			t.Errorf("Unexpected result error: %s", err)
+		}
+	}
+
+	central := newSomatotype(70, 175, [4]float64{10, 12, 8, 8}, [2]float64{29, 34}, [2]float64{6.5, 9.0})
+	if category, _ := central.Classify(); category != SomatotypeClassification[SomatotypeCentral] {
+		t.Errorf("Category expected Central, got %s", category)
+	}
+
+	missing := NewSomatotype(
+		NewAnthropometry(70, 175),
+		NewSkinfolds(map[int]float64{SKFTriceps: 10, SKFSubscapular: 12, SKFCalf: 8}),
+		NewCircumferences(map[int]float64{CCFRightFlexedArm: 32, CCFRightCalf: 36}),
+		NewBreadths(map[int]float64{BDHumerus: 7.0, BDFemur: 9.7}),
+	)
+	if _, err := missing.Components(); err == nil || !strings.Contains(err.Error(), "Missing supraspinale measure") {
+		t.Errorf("Expected missing supraspinale error, got %v", err)
+	}
+
+	relaxed := NewSomatotype(
+		NewAnthropometry(70, 175),
+		NewSkinfolds(map[int]float64{SKFTriceps: 10, SKFSubscapular: 12, SKFSupraspinale: 8, SKFCalf: 8}),
+		NewCircumferences(map[int]float64{CCFRightArm: 32, CCFRightCalf: 36}),
+		NewBreadths(map[int]float64{BDHumerus: 7.0, BDFemur: 9.7}),
+	)
+	if _, err := relaxed.Mesomorphy(); err == nil || !strings.Contains(err.Error(), "Missing right flexed arm measure") {
+		t.Errorf("Expected missing right flexed arm error, got %v", err)
+	}
+
+	for _, s := range []*Somatotype{
+		NewSomatotype(nil, nil, nil, nil),
+		NewSomatotype(NewAnthropometry(70, 175), nil, nil, nil),
+	} {
+		if _, err := s.Components(); err == nil || !strings.Contains(err.Error(), "Missing") {
+			t.Errorf("Expected missing measure error, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * One repetition maximum equations
+ */
+
+// One repetition maximum estimation for different equations.
+var (
+	NewEpley    = FactoryOneRepMax(epleyConf)
+	NewBrzycki  = FactoryOneRepMax(brzyckiConf)
+	NewLombardi = FactoryOneRepMax(lombardiConf)
+	NewMayhew   = FactoryOneRepMax(mayhewConf)
+	NewOConner  = FactoryOneRepMax(oConnerConf)
+	NewWathan   = FactoryOneRepMax(wathanConf)
+)
+
+/**
+ * One repetition maximum
+ */
+
+// OneRepMax contains data needed to estimate the one repetition maximum from
+// a submaximal load test. This is a composition of anthropometry, the
+// exercise performed, the load lifted (in kg), the repetitions completed and
+// an equation.
+type OneRepMax struct {
+	*Anthropometry
+	Exercise string
+	Load     float64
+	Reps     float64
+	*EquationConf
+}
+
+// FactoryOneRepMax factory to create new one repetition maximum estimations.
+// It returns a function to create new OneRepMax structs.
+func FactoryOneRepMax(conf *EquationConf) func(*Anthropometry, string, float64, float64) *OneRepMax {
+	return func(a *Anthropometry, exercise string, load, reps float64) *OneRepMax {
+		return NewOneRepMax(a, exercise, load, reps, conf)
+	}
+}
+
+// NewOneRepMax create a new one repetition maximum estimation. It receives
+// anthropometry, the exercise, load lifted, repetitions completed, and the
+// equation used. Returns a pointer to OneRepMax.
+func NewOneRepMax(a *Anthropometry, exercise string, load, reps float64, e *EquationConf) *OneRepMax {
+	return &OneRepMax{a, exercise, load, reps, e}
+}
+
+func (o *OneRepMax) String() string {
+	v, _ := o.Calc()
+	return fmt.Sprintf("1RM %s: %.2f kg", o.Exercise, v)
+}
+
+// GetName returns this measurement name.
+func (o *OneRepMax) GetName() string {
+	return "One repetition maximum"
+}
+
+// Result returns information about the one repetition maximum estimation.
+func (o *OneRepMax) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := o.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	r, err := o.Relative()
+	if err != nil {
+		return rs, err
+	}
+
+	rs = append(
+		rs,
+		fmt.Sprintf("Exercise: %s, %.2f kg x %.0f reps", o.Exercise, o.Load, o.Reps),
+		fmt.Sprintf("1RM (%s): %.2f kg", o.EquationConf.Name, v),
+		fmt.Sprintf("Relative strength: %.2f kg/kg", r),
+	)
+	return rs, nil
+}
+
+// Calc returns the estimated one repetition maximum, in kg.
+func (o *OneRepMax) Calc() (float64, error) {
+	return o.equation().Calc()
+}
+
+// Relative returns the estimated one repetition maximum relative to body
+// weight.
+func (o *OneRepMax) Relative() (float64, error) {
+	v, err := o.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	if o.Anthropometry == nil || o.Anthropometry.Weight <= 0 {
+		return 0.0, fmt.Errorf("Missing weight measure")
+	}
+	return v / o.Anthropometry.Weight, nil
+}
+
+// equation returns an equation, used to estimate one repetition maximum.
+func (o *OneRepMax) equation() Equationer {
+	return NewEquation(o.EquationConf.Extract(o), o.EquationConf)
+}
+
+/**
+ * Equations
+ */
+
+// Equations to estimate one repetition maximum from load and repetitions.
+var (
+	epleyConf = newOneRepMaxConf("Epley", func(w, r float64) float64 {
+		return w * (1 + r/30)
+	})
+	brzyckiConf = newOneRepMaxConf("Brzycki", func(w, r float64) float64 {
+		return w * 36 / (37 - r)
+	})
+	lombardiConf = newOneRepMaxConf("Lombardi", func(w, r float64) float64 {
+		return w * math.Pow(r, 0.10)
+	})
+	mayhewConf = newOneRepMaxConf("Mayhew et al.", func(w, r float64) float64 {
+		return 100 * w / (52.2 + 41.9*math.Exp(-0.055*r))
+	})
+	oConnerConf = newOneRepMaxConf("O'Conner et al.", func(w, r float64) float64 {
+		return w * (1 + 0.025*r)
+	})
+	wathanConf = newOneRepMaxConf("Wathan", func(w, r float64) float64 {
+		return 100 * w / (48.8 + 53.8*math.Exp(-0.075*r))
+	})
+)
+
+// newOneRepMaxConf returns an equation configuration for one repetition
+// maximum, valid for loads lifted up to 10 repetitions. A load lifted a
+// single time is already the maximum, and is returned by every equation.
+func newOneRepMaxConf(name string, eq func(float64, float64) float64) *EquationConf {
+	return NewEquationConf(
+		name,
+		func(i interface{}) InParams {
+			o := i.(*OneRepMax)
+			return map[string]float64{
+				"load": o.Load,
+				"reps": o.Reps,
+			}
+		},
+		[]Validator{
+			ValidateMeasures([]string{"load", "reps"}),
+			func(e *Equation) (bool, error) {
+				if w, _ := e.In("load"); w <= 0 {
+					return false, fmt.Errorf("Load must be greater than zero")
+				}
+				return true, nil
+			},
+			ValidateRange("reps", 1, 10),
+		},
+		func(e *Equation) float64 {
+			w, _ := e.In("load")
+			r, _ := e.In("reps")
+			if r == 1 {
+				return w
+			}
+			return eq(w, r)
+		},
+	)
+}
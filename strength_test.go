@@ -0,0 +1,71 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOneRepMaxCalc(t *testing.T) {
+	a := NewAnthropometry(80, 175)
+
+	type ormCase struct {
+		orm      *OneRepMax
+		calc     float64
+		relative float64
+	}
+
+	cases := []ormCase{
+		{NewEpley(a, "bench press", 100, 5), 116.6667, 1.4583},
+		{NewBrzycki(a, "bench press", 100, 5), 112.5, 1.4063},
+		{NewLombardi(a, "bench press", 100, 5), 117.4619, 1.4683},
+		{NewMayhew(a, "bench press", 100, 5), 119.0107, 1.4876},
+		{NewOConner(a, "bench press", 100, 5), 112.5, 1.4063},
+		{NewWathan(a, "bench press", 100, 5), 116.5825, 1.4573},
+		{NewEpley(a, "squat", 140, 1), 140, 1.75},
+		{NewBrzycki(a, "squat", 140, 1), 140, 1.75},
+		{NewLombardi(a, "squat", 140, 1), 140, 1.75},
+		{NewMayhew(a, "squat", 140, 1), 140, 1.75},
+		{NewOConner(a, "squat", 140, 1), 140, 1.75},
+		{NewWathan(a, "squat", 140, 1), 140, 1.75},
+	}
+
+	for _, data := range cases {
+		name := data.orm.EquationConf.Name
+		if calc, err := data.orm.Calc(); err != nil {
+			t.Errorf("Equation %s should not fail: %s", name, err)
+		} else if !floatEqual(calc, data.calc, 0.001) {
+			t.Errorf("Equation %s calc is %.4f, expected is %.4f", name, calc, data.calc)
+		}
+		if r, _ := data.orm.Relative(); !floatEqual(r, data.relative, 0.0001) {
+			t.Errorf("Equation %s relative is %.4f, expected is %.4f", name, r, data.relative)
+		}
+		if rs, err := data.orm.Result(); err != nil || len(rs) != 3 {
+			t.Errorf("Equation %s should have a result, instead got %v", name, err)
+		}
+	}
+}
+
+func TestOneRepMaxValidation(t *testing.T) {
+	a := NewAnthropometry(80, 175)
+
+	type ormCase struct {
+		orm *OneRepMax
+		err string
+	}
+
+	cases := []ormCase{
+		{NewBrzycki(a, "bench press", 60, 12), "Valid for reps"},
+		{NewBrzycki(a, "bench press", 60, 0), "Valid for reps"},
+		{NewBrzycki(a, "bench press", 0, 5), "Load must be greater than zero"},
+		{NewBrzycki(NewAnthropometry(0, 175), "bench press", 60, 5), "Missing weight"},
+	}
+
+	for _, data := range cases {
+		if _, err := data.orm.Relative(); err == nil || !strings.Contains(err.Error(), data.err) {
+			t.Errorf("Should show proper error message %s, got %v", data.err, err)
+		}
+		if _, err := data.orm.Result(); err == nil {
+			t.Error("Result should show an error")
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package phass
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+/**
+ * Treadmill protocols
+ */
+
+// Graded exercise treadmill protocols to estimate maximal oxygen uptake.
+var (
+	NewBruce         = FactoryTreadmill(bruceConf)
+	NewModifiedBruce = FactoryTreadmill(modifiedBruceConf)
+	NewBalkeWare     = FactoryTreadmill(balkeWareConf)
+)
+
+/**
+ * Treadmill
+ */
+
+// Treadmill contains data needed to estimate the maximal oxygen uptake from a
+// graded exercise treadmill test. This is a composition of a person,
+// assessment details, total test time (in minutes) and an equation. The
+// equation is selected by the population declared by the person.
+type Treadmill struct {
+	*Person
+	*Assessment
+	Time float64
+	*EquationConf
+}
+
+// FactoryTreadmill factory to create new treadmill tests. It returns a
+// function to create new Treadmill structs.
+func FactoryTreadmill(conf *EquationConf) func(*Person, *Assessment, float64) *Treadmill {
+	return func(p *Person, a *Assessment, time float64) *Treadmill {
+		return NewTreadmill(p, a, time, conf)
+	}
+}
+
+// NewTreadmill create a new treadmill test. It receives a person, an
+// assessment, total test time, and the equation used to estimate maximal
+// oxygen uptake. Returns a pointer to Treadmill.
+func NewTreadmill(p *Person, a *Assessment, time float64, e *EquationConf) *Treadmill {
+	return &Treadmill{p, a, time, e}
+}
+
+func (t *Treadmill) String() string {
+	v, _ := t.Calc()
+	return fmt.Sprintf("VO2max: %.2f ml/kg/min", v)
+}
+
+// GetName returns this measurement name.
+func (t *Treadmill) GetName() string {
+	return "Treadmill"
+}
+
+// Result returns information about the treadmill test.
+func (t *Treadmill) Result() ([]string, error) {
+	rs := []string{}
+
+	v, err := t.Calc()
+	if err != nil {
+		return rs, err
+	}
+
+	mets, err := t.METs()
+	if err != nil {
+		return rs, err
+	}
+
+	rs = append(
+		rs,
+		fmt.Sprintf("Protocol: %s (%s)", t.EquationConf.Name, NamedPopulation(t.Person.Population)),
+		fmt.Sprintf("Total time: %.2f min", t.Time),
+		fmt.Sprintf("VO2max: %.2f ml/kg/min", v),
+		fmt.Sprintf("METs: %.1f", mets),
+	)
+	return rs, nil
+}
+
+// Calc returns the estimated maximal oxygen uptake, in ml/kg/min.
+func (t *Treadmill) Calc() (float64, error) {
+	return t.equation().Calc()
+}
+
+// METs returns the estimated maximal oxygen uptake as metabolic equivalents.
+func (t *Treadmill) METs() (float64, error) {
+	v, err := t.Calc()
+	if err != nil {
+		return 0.0, err
+	}
+	return v / restingVO2, nil
+}
+
+// equation returns an equation, used to estimate maximal oxygen uptake.
+func (t *Treadmill) equation() Equationer {
+	return NewEquation(t.EquationConf.Extract(t), t.EquationConf)
+}
+
+/**
+ * Equations
+ */
+
+var (
+	bruceConf = NewEquationConf(
+		"Bruce",
+		treadmillInParams,
+		treadmillValidators(1, 21, PopulationHealthy, PopulationCardiac, PopulationAthlete),
+		bruceEquation(0),
+	)
+	modifiedBruceConf = NewEquationConf(
+		"Modified Bruce",
+		treadmillInParams,
+		treadmillValidators(6, 27, PopulationHealthy, PopulationCardiac, PopulationAthlete),
+		// the modified protocol prepends two 3 min stages to the standard
+		// Bruce protocol, once completed the protocols are equivalent.
+		bruceEquation(6),
+	)
+	balkeWareConf = NewEquationConf(
+		"Balke-Ware",
+		treadmillInParams,
+		// equations were developed for healthy adults only.
+		treadmillValidators(1, 26, PopulationHealthy, PopulationAthlete),
+		func(e *Equation) float64 {
+			gender, _ := e.In("gender")
+			t, _ := e.In("time")
+			if int(gender) == Female {
+				return 1.38*t + 5.22
+			}
+			return 1.444*t + 14.99
+		},
+	)
+)
+
+// bruceEquation returns the Bruce protocol calculator, with total time
+// reduced by the given offset in minutes. Healthy men use the equation from
+// Foster et al., healthy women from Pollock et al., and cardiac patients the
+// equation from Bruce et al.
+func bruceEquation(offset float64) Calculator {
+	return func(e *Equation) float64 {
+		gender, _ := e.In("gender")
+		population, _ := e.In("population")
+		t, _ := e.In("time")
+		t -= offset
+		switch {
+		case int(population) == PopulationCardiac:
+			return 2.327*t + 9.48
+		case int(gender) == Female:
+			return 4.38*t - 3.90
+		}
+		return 14.76 - 1.379*t + 0.451*math.Pow(t, 2) - 0.012*math.Pow(t, 3)
+	}
+}
+
+// treadmillValidators returns common validators for treadmill protocols,
+// ensuring total time is within the protocol limits, and the population is
+// one of the populations the protocol equations are valid for.
+func treadmillValidators(lower, upper float64, populations ...int) []Validator {
+	return []Validator{
+		ValidateMeasures([]string{"gender", "population", "time"}),
+		func(e *Equation) (bool, error) {
+			v, _ := e.In("population")
+			names := []string{}
+			for _, p := range populations {
+				if int(v) == p {
+					return true, nil
+				}
+				names = append(names, NamedPopulation(p))
+			}
+			return false, fmt.Errorf("Valid for population %s", strings.Join(names, ", "))
+		},
+		ValidateRange("time", lower, upper),
+	}
+}
+
+// treadmillInParams extract input parameters from a Treadmill.
+func treadmillInParams(i interface{}) InParams {
+	t := i.(*Treadmill)
+	return map[string]float64{
+		"gender":     float64(t.Person.Gender),
+		"population": float64(t.Person.Population),
+		"time":       t.Time,
+	}
+}
+
+// restingVO2 represents the oxygen uptake at rest (1 MET), in ml/kg/min.
+const restingVO2 = 3.5
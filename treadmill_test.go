@@ -0,0 +1,63 @@
+package phass
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTreadmillCalc(t *testing.T) {
+	assessment, _ := NewAssessment("2015-May-22")
+	cardiac, unknown := *male, *male
+	cardiac.Population = PopulationCardiac
+	unknown.Population = 10
+
+	type treadmillCase struct {
+		treadmill *Treadmill
+		name      string
+		calc      float64
+		mets      float64
+		err       string
+	}
+
+	cases := []treadmillCase{
+		{NewBruce(male, assessment, 9), "bruce-healthy-male", 30.132, 8.609, ""},
+		{NewBruce(male, assessment, 12), "bruce-healthy-male", 42.420, 12.12, ""},
+		{NewBruce(female, assessment, 9), "bruce-healthy-female", 35.520, 10.149, ""},
+		{NewBruce(&cardiac, assessment, 9), "bruce-cardiac", 30.423, 8.692, ""},
+		{NewBruce(male, assessment, 22), "bruce-too-long", 0, 0, "Valid for time"},
+		{NewBruce(&unknown, assessment, 9), "bruce-unknown-population", 0, 0, "Valid for population healthy, cardiac, athlete"},
+		{NewModifiedBruce(male, assessment, 15), "modified-bruce-healthy-male", 30.132, 8.609, ""},
+		{NewModifiedBruce(female, assessment, 15), "modified-bruce-healthy-female", 35.520, 10.149, ""},
+		{NewModifiedBruce(male, assessment, 5), "modified-bruce-too-short", 0, 0, "Valid for time"},
+		{NewBalkeWare(male, assessment, 20), "balke-ware-male", 43.870, 12.534, ""},
+		{NewBalkeWare(female, assessment, 20), "balke-ware-female", 32.820, 9.377, ""},
+		{NewBalkeWare(&cardiac, assessment, 20), "balke-ware-cardiac", 0, 0, "Valid for population healthy, athlete"},
+	}
+
+	for _, data := range cases {
+		calc, err := data.treadmill.Calc()
+		if data.err != "" {
+			if err == nil || !strings.Contains(err.Error(), data.err) {
+				t.Errorf("Case _%s_ failed, should show proper error message, got %v", data.name, err)
+			}
+			if _, err := data.treadmill.Result(); err == nil {
+				t.Errorf("Case _%s_ failed, result should show a validation error", data.name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Case _%s_ failed, unexpected error %s", data.name, err)
+		} else if !floatEqual(calc, data.calc, 0.001) {
+			t.Errorf("Case _%s_ failed, should have value %.4f, instead got %.4f", data.name, data.calc, calc)
+		}
+
+		if mets, _ := data.treadmill.METs(); !floatEqual(mets, data.mets, 0.001) {
+			t.Errorf("Case _%s_ failed, should have %.3f METs, instead got %.3f", data.name, data.mets, mets)
+		}
+
+		if rs, err := data.treadmill.Result(); err != nil || len(rs) != 4 {
+			t.Errorf("Case _%s_ failed, should have a result, instead got %v", data.name, err)
+		}
+	}
+}